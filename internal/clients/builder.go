@@ -29,6 +29,7 @@ type ClientBuilder struct {
 	MetadataHost                string
 	PartnerID                   string
 	RegisteredResourceProviders resourceproviders.ResourceProviders
+	StorageDomainSuffix         string
 	StorageUseAzureAD           bool
 	SubscriptionID              string
 	TerraformVersion            string
@@ -150,6 +151,7 @@ func Build(ctx context.Context, builder ClientBuilder) (*Client, error) {
 		DisableCorrelationRequestID: builder.DisableCorrelationRequestID,
 		DisableTerraformPartnerID:   builder.DisableTerraformPartnerID,
 		SkipProviderReg:             len(builder.RegisteredResourceProviders) == 0,
+		StorageDomainSuffix:         builder.StorageDomainSuffix,
 		StorageUseAzureAD:           builder.StorageUseAzureAD,
 
 		ResourceManagerEndpoint: *resourceManagerEndpoint,
@@ -48,6 +48,9 @@ type ClientOptions struct {
 	DisableTerraformPartnerID bool
 	StorageUseAzureAD         bool
 
+	// StorageDomainSuffix optionally overrides the Storage Domain Suffix from the Environment
+	StorageDomainSuffix string
+
 	ResourceManagerEndpoint string
 
 	// Legacy authorizers for go-autorest
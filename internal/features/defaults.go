@@ -75,5 +75,13 @@ func Default() UserFeatures {
 			VMBackupStopProtectionAndRetainDataOnDestroy: false,
 			PurgeProtectedItemsFromVaultOnDestroy:        false,
 		},
+		Storage: StorageFeatures{
+			DataPlaneAccessOnReadDefault:           true,
+			SkipKeyRetrieval:                       false,
+			SubnetServiceEndpointValidationEnabled: false,
+			InheritResourceGroupTags:               false,
+			IgnoredTagPrefixes:                     []string{"hidden-link:", "hidden-related:"},
+			SkipVirtualNetworkLockingOnDestroy:     false,
+		},
 	}
 }
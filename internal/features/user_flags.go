@@ -20,6 +20,7 @@ type UserFeatures struct {
 	PostgresqlFlexibleServer PostgresqlFlexibleServerFeatures
 	MachineLearning          MachineLearningFeatures
 	RecoveryService          RecoveryServiceFeatures
+	Storage                  StorageFeatures
 }
 
 type CognitiveAccountFeatures struct {
@@ -100,6 +101,15 @@ type MachineLearningFeatures struct {
 	PurgeSoftDeletedWorkspaceOnDestroy bool
 }
 
+type StorageFeatures struct {
+	DataPlaneAccessOnReadDefault           bool
+	SkipKeyRetrieval                       bool
+	SubnetServiceEndpointValidationEnabled bool
+	InheritResourceGroupTags               bool
+	IgnoredTagPrefixes                     []string
+	SkipVirtualNetworkLockingOnDestroy     bool
+}
+
 type RecoveryServiceFeatures struct {
 	VMBackupStopProtectionAndRetainDataOnDestroy bool
 	PurgeProtectedItemsFromVaultOnDestroy        bool
@@ -372,6 +372,48 @@ func schemaFeatures(supportLegacyTestSuite bool) *pluginsdk.Schema {
 				},
 			},
 		},
+
+		"storage": {
+			Type:     pluginsdk.TypeList,
+			Optional: true,
+			MaxItems: 1,
+			Elem: &pluginsdk.Resource{
+				Schema: map[string]*pluginsdk.Schema{
+					"data_plane_access_on_read_default": {
+						Type:     pluginsdk.TypeBool,
+						Optional: true,
+						Default:  true,
+					},
+					"skip_key_retrieval": {
+						Type:     pluginsdk.TypeBool,
+						Optional: true,
+						Default:  false,
+					},
+					"subnet_service_endpoint_validation_enabled": {
+						Type:     pluginsdk.TypeBool,
+						Optional: true,
+						Default:  false,
+					},
+					"inherit_resource_group_tags": {
+						Type:     pluginsdk.TypeBool,
+						Optional: true,
+						Default:  false,
+					},
+					"ignored_tag_prefixes": {
+						Type:     pluginsdk.TypeList,
+						Optional: true,
+						Elem: &pluginsdk.Schema{
+							Type: pluginsdk.TypeString,
+						},
+					},
+					"skip_virtual_network_locking_on_destroy": {
+						Type:     pluginsdk.TypeBool,
+						Optional: true,
+						Default:  false,
+					},
+				},
+			},
+		},
 	}
 
 	// this is a temporary hack to enable us to gradually add provider blocks to test configurations
@@ -624,5 +666,34 @@ func expandFeatures(input []interface{}) features.UserFeatures {
 		}
 	}
 
+	if raw, ok := val["storage"]; ok {
+		items := raw.([]interface{})
+		if len(items) > 0 {
+			storageRaw := items[0].(map[string]interface{})
+			if v, ok := storageRaw["data_plane_access_on_read_default"]; ok {
+				featuresMap.Storage.DataPlaneAccessOnReadDefault = v.(bool)
+			}
+			if v, ok := storageRaw["skip_key_retrieval"]; ok {
+				featuresMap.Storage.SkipKeyRetrieval = v.(bool)
+			}
+			if v, ok := storageRaw["subnet_service_endpoint_validation_enabled"]; ok {
+				featuresMap.Storage.SubnetServiceEndpointValidationEnabled = v.(bool)
+			}
+			if v, ok := storageRaw["inherit_resource_group_tags"]; ok {
+				featuresMap.Storage.InheritResourceGroupTags = v.(bool)
+			}
+			if v, ok := storageRaw["ignored_tag_prefixes"]; ok && len(v.([]interface{})) > 0 {
+				prefixes := make([]string, 0)
+				for _, prefix := range v.([]interface{}) {
+					prefixes = append(prefixes, prefix.(string))
+				}
+				featuresMap.Storage.IgnoredTagPrefixes = prefixes
+			}
+			if v, ok := storageRaw["skip_virtual_network_locking_on_destroy"]; ok {
+				featuresMap.Storage.SkipVirtualNetworkLockingOnDestroy = v.(bool)
+			}
+		}
+	}
+
 	return featuresMap
 }
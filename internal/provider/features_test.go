@@ -88,6 +88,11 @@ func TestExpandFeatures(t *testing.T) {
 					VMBackupStopProtectionAndRetainDataOnDestroy: false,
 					PurgeProtectedItemsFromVaultOnDestroy:        false,
 				},
+				Storage: features.StorageFeatures{
+					DataPlaneAccessOnReadDefault: true,
+					SkipKeyRetrieval:             false,
+					IgnoredTagPrefixes:           []string{"hidden-link:", "hidden-related:"},
+				},
 			},
 		},
 		{
@@ -193,6 +198,16 @@ func TestExpandFeatures(t *testing.T) {
 							"purge_protected_items_from_vault_on_destroy":          true,
 						},
 					},
+					"storage": []interface{}{
+						map[string]interface{}{
+							"data_plane_access_on_read_default":          true,
+							"skip_key_retrieval":                         true,
+							"subnet_service_endpoint_validation_enabled": true,
+							"inherit_resource_group_tags":                true,
+							"ignored_tag_prefixes":                       []interface{}{"hidden-link:", "managed-by:"},
+							"skip_virtual_network_locking_on_destroy":    true,
+						},
+					},
 				},
 			},
 			Expected: features.UserFeatures{
@@ -263,6 +278,14 @@ func TestExpandFeatures(t *testing.T) {
 					VMBackupStopProtectionAndRetainDataOnDestroy: true,
 					PurgeProtectedItemsFromVaultOnDestroy:        true,
 				},
+				Storage: features.StorageFeatures{
+					DataPlaneAccessOnReadDefault:           true,
+					SkipKeyRetrieval:                       true,
+					SubnetServiceEndpointValidationEnabled: true,
+					InheritResourceGroupTags:               true,
+					IgnoredTagPrefixes:                     []string{"hidden-link:", "managed-by:"},
+					SkipVirtualNetworkLockingOnDestroy:     true,
+				},
 			},
 		},
 		{
@@ -368,6 +391,15 @@ func TestExpandFeatures(t *testing.T) {
 							"purge_protected_items_from_vault_on_destroy":          false,
 						},
 					},
+					"storage": []interface{}{
+						map[string]interface{}{
+							"data_plane_access_on_read_default":          false,
+							"skip_key_retrieval":                         false,
+							"subnet_service_endpoint_validation_enabled": false,
+							"inherit_resource_group_tags":                false,
+							"skip_virtual_network_locking_on_destroy":    false,
+						},
+					},
 				},
 			},
 			Expected: features.UserFeatures{
@@ -438,6 +470,11 @@ func TestExpandFeatures(t *testing.T) {
 					VMBackupStopProtectionAndRetainDataOnDestroy: false,
 					PurgeProtectedItemsFromVaultOnDestroy:        false,
 				},
+				Storage: features.StorageFeatures{
+					DataPlaneAccessOnReadDefault: false,
+					SkipKeyRetrieval:             false,
+					IgnoredTagPrefixes:           []string{"hidden-link:", "hidden-related:"},
+				},
 			},
 		},
 	}
@@ -1678,3 +1715,169 @@ func TestExpandFeaturesRecoveryService(t *testing.T) {
 		}
 	}
 }
+
+func TestExpandFeaturesStorage(t *testing.T) {
+	testData := []struct {
+		Name     string
+		Input    []interface{}
+		EnvVars  map[string]interface{}
+		Expected features.UserFeatures
+	}{
+		{
+			Name: "Empty Block",
+			Input: []interface{}{
+				map[string]interface{}{
+					"storage": []interface{}{},
+				},
+			},
+			Expected: features.UserFeatures{
+				Storage: features.StorageFeatures{
+					DataPlaneAccessOnReadDefault: true,
+					SkipKeyRetrieval:             false,
+					IgnoredTagPrefixes:           []string{"hidden-link:", "hidden-related:"},
+				},
+			},
+		},
+		{
+			Name: "Storage Skip Key Retrieval Enabled",
+			Input: []interface{}{
+				map[string]interface{}{
+					"storage": []interface{}{
+						map[string]interface{}{
+							"skip_key_retrieval": true,
+						},
+					},
+				},
+			},
+			Expected: features.UserFeatures{
+				Storage: features.StorageFeatures{
+					DataPlaneAccessOnReadDefault: true,
+					SkipKeyRetrieval:             true,
+					IgnoredTagPrefixes:           []string{"hidden-link:", "hidden-related:"},
+				},
+			},
+		},
+		{
+			Name: "Storage Skip Key Retrieval Disabled",
+			Input: []interface{}{
+				map[string]interface{}{
+					"storage": []interface{}{
+						map[string]interface{}{
+							"skip_key_retrieval": false,
+						},
+					},
+				},
+			},
+			Expected: features.UserFeatures{
+				Storage: features.StorageFeatures{
+					DataPlaneAccessOnReadDefault: true,
+					SkipKeyRetrieval:             false,
+					IgnoredTagPrefixes:           []string{"hidden-link:", "hidden-related:"},
+				},
+			},
+		},
+		{
+			Name: "Storage Subnet Service Endpoint Validation Enabled",
+			Input: []interface{}{
+				map[string]interface{}{
+					"storage": []interface{}{
+						map[string]interface{}{
+							"subnet_service_endpoint_validation_enabled": true,
+						},
+					},
+				},
+			},
+			Expected: features.UserFeatures{
+				Storage: features.StorageFeatures{
+					DataPlaneAccessOnReadDefault:           true,
+					SkipKeyRetrieval:                       false,
+					SubnetServiceEndpointValidationEnabled: true,
+					IgnoredTagPrefixes:                     []string{"hidden-link:", "hidden-related:"},
+				},
+			},
+		},
+		{
+			Name: "Storage Inherit Resource Group Tags Enabled",
+			Input: []interface{}{
+				map[string]interface{}{
+					"storage": []interface{}{
+						map[string]interface{}{
+							"inherit_resource_group_tags": true,
+						},
+					},
+				},
+			},
+			Expected: features.UserFeatures{
+				Storage: features.StorageFeatures{
+					DataPlaneAccessOnReadDefault: true,
+					InheritResourceGroupTags:     true,
+					IgnoredTagPrefixes:           []string{"hidden-link:", "hidden-related:"},
+				},
+			},
+		},
+		{
+			Name: "Storage Ignored Tag Prefixes",
+			Input: []interface{}{
+				map[string]interface{}{
+					"storage": []interface{}{
+						map[string]interface{}{
+							"ignored_tag_prefixes": []interface{}{"managed-by:"},
+						},
+					},
+				},
+			},
+			Expected: features.UserFeatures{
+				Storage: features.StorageFeatures{
+					DataPlaneAccessOnReadDefault: true,
+					IgnoredTagPrefixes:           []string{"managed-by:"},
+				},
+			},
+		},
+		{
+			Name: "Storage Skip Virtual Network Locking On Destroy Enabled",
+			Input: []interface{}{
+				map[string]interface{}{
+					"storage": []interface{}{
+						map[string]interface{}{
+							"skip_virtual_network_locking_on_destroy": true,
+						},
+					},
+				},
+			},
+			Expected: features.UserFeatures{
+				Storage: features.StorageFeatures{
+					DataPlaneAccessOnReadDefault:       true,
+					IgnoredTagPrefixes:                 []string{"hidden-link:", "hidden-related:"},
+					SkipVirtualNetworkLockingOnDestroy: true,
+				},
+			},
+		},
+		{
+			Name: "Storage Data Plane Access On Read Default Disabled",
+			Input: []interface{}{
+				map[string]interface{}{
+					"storage": []interface{}{
+						map[string]interface{}{
+							"data_plane_access_on_read_default": false,
+						},
+					},
+				},
+			},
+			Expected: features.UserFeatures{
+				Storage: features.StorageFeatures{
+					DataPlaneAccessOnReadDefault: false,
+					SkipKeyRetrieval:             false,
+					IgnoredTagPrefixes:           []string{"hidden-link:", "hidden-related:"},
+				},
+			},
+		},
+	}
+
+	for _, testCase := range testData {
+		t.Logf("[DEBUG] Test Case: %q", testCase.Name)
+		result := expandFeatures(testCase.Input)
+		if !reflect.DeepEqual(result.Storage, testCase.Expected.Storage) {
+			t.Fatalf("Expected %+v but got %+v", result.Storage, testCase.Expected.Storage)
+		}
+	}
+}
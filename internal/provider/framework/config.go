@@ -126,6 +126,13 @@ func (p *ProviderConfig) Load(ctx context.Context, data *ProviderModel, tfVersio
 	p.clientBuilder.DisableTerraformPartnerID = getEnvBoolOrDefault(data.DisableTerraformPartnerId, "ARM_DISABLE_TERRAFORM_PARTNER_ID", false)
 	p.clientBuilder.StorageUseAzureAD = getEnvBoolOrDefault(data.StorageUseAzureAD, "ARM_STORAGE_USE_AZUREAD", false)
 
+	storageDomainSuffix := getEnvStringIfValueAbsent(data.StorageDomainSuffix, "ARM_STORAGE_DOMAIN_SUFFIX")
+	if _, errs := provider.ValidateStorageDomainSuffix(storageDomainSuffix, "ARM_STORAGE_DOMAIN_SUFFIX"); len(errs) > 0 {
+		diags.Append(diag.NewErrorDiagnostic("validating ARM_STORAGE_DOMAIN_SUFFIX", errs[0].Error()))
+		return
+	}
+	p.clientBuilder.StorageDomainSuffix = storageDomainSuffix
+
 	f := providerfeatures.UserFeatures{}
 
 	// features is required, but we'll play safe here
@@ -468,6 +475,60 @@ func (p *ProviderConfig) Load(ctx context.Context, data *ProviderModel, tfVersio
 			f.RecoveryService.VMBackupStopProtectionAndRetainDataOnDestroy = false
 			f.RecoveryService.PurgeProtectedItemsFromVaultOnDestroy = false
 		}
+
+		if !features.Storage.IsNull() && !features.Storage.IsUnknown() {
+			var feature []Storage
+			d := features.Storage.ElementsAs(ctx, &feature, true)
+			diags.Append(d...)
+			if diags.HasError() {
+				return
+			}
+
+			f.Storage.DataPlaneAccessOnReadDefault = true
+			if !feature[0].DataPlaneAccessOnReadDefault.IsNull() && !feature[0].DataPlaneAccessOnReadDefault.IsUnknown() {
+				f.Storage.DataPlaneAccessOnReadDefault = feature[0].DataPlaneAccessOnReadDefault.ValueBool()
+			}
+
+			f.Storage.SkipKeyRetrieval = false
+			if !feature[0].SkipKeyRetrieval.IsNull() && !feature[0].SkipKeyRetrieval.IsUnknown() {
+				f.Storage.SkipKeyRetrieval = feature[0].SkipKeyRetrieval.ValueBool()
+			}
+
+			f.Storage.SubnetServiceEndpointValidationEnabled = false
+			if !feature[0].SubnetServiceEndpointValidationEnabled.IsNull() && !feature[0].SubnetServiceEndpointValidationEnabled.IsUnknown() {
+				f.Storage.SubnetServiceEndpointValidationEnabled = feature[0].SubnetServiceEndpointValidationEnabled.ValueBool()
+			}
+
+			f.Storage.InheritResourceGroupTags = false
+			if !feature[0].InheritResourceGroupTags.IsNull() && !feature[0].InheritResourceGroupTags.IsUnknown() {
+				f.Storage.InheritResourceGroupTags = feature[0].InheritResourceGroupTags.ValueBool()
+			}
+
+			f.Storage.IgnoredTagPrefixes = []string{"hidden-link:", "hidden-related:"}
+			if !feature[0].IgnoredTagPrefixes.IsNull() && !feature[0].IgnoredTagPrefixes.IsUnknown() {
+				var prefixes []string
+				d := feature[0].IgnoredTagPrefixes.ElementsAs(ctx, &prefixes, false)
+				diags.Append(d...)
+				if diags.HasError() {
+					return
+				}
+				if len(prefixes) > 0 {
+					f.Storage.IgnoredTagPrefixes = prefixes
+				}
+			}
+
+			f.Storage.SkipVirtualNetworkLockingOnDestroy = false
+			if !feature[0].SkipVirtualNetworkLockingOnDestroy.IsNull() && !feature[0].SkipVirtualNetworkLockingOnDestroy.IsUnknown() {
+				f.Storage.SkipVirtualNetworkLockingOnDestroy = feature[0].SkipVirtualNetworkLockingOnDestroy.ValueBool()
+			}
+		} else {
+			f.Storage.DataPlaneAccessOnReadDefault = true
+			f.Storage.SkipKeyRetrieval = false
+			f.Storage.SubnetServiceEndpointValidationEnabled = false
+			f.Storage.InheritResourceGroupTags = false
+			f.Storage.IgnoredTagPrefixes = []string{"hidden-link:", "hidden-related:"}
+			f.Storage.SkipVirtualNetworkLockingOnDestroy = false
+		}
 	}
 
 	p.clientBuilder.Features = f
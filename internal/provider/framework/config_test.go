@@ -7,6 +7,7 @@ import (
 	"context"
 	"fmt"
 	"os"
+	"reflect"
 	"testing"
 
 	"github.com/hashicorp/terraform-plugin-framework/attr"
@@ -200,6 +201,30 @@ func TestProviderConfig_LoadDefault(t *testing.T) {
 	if features.RecoveryService.PurgeProtectedItemsFromVaultOnDestroy {
 		t.Errorf("expected recovery_service.PurgeProtectedItemsFromVaultOnDestroy to be false")
 	}
+
+	if !features.Storage.DataPlaneAccessOnReadDefault {
+		t.Errorf("expected storage.data_plane_access_on_read_default to be true")
+	}
+
+	if features.Storage.SkipKeyRetrieval {
+		t.Errorf("expected storage.skip_key_retrieval to be false")
+	}
+
+	if features.Storage.SubnetServiceEndpointValidationEnabled {
+		t.Errorf("expected storage.subnet_service_endpoint_validation_enabled to be false")
+	}
+
+	if features.Storage.InheritResourceGroupTags {
+		t.Errorf("expected storage.inherit_resource_group_tags to be false")
+	}
+
+	if !reflect.DeepEqual(features.Storage.IgnoredTagPrefixes, []string{"hidden-link:", "hidden-related:"}) {
+		t.Errorf("expected storage.ignored_tag_prefixes to be the default prefixes but got %+v", features.Storage.IgnoredTagPrefixes)
+	}
+
+	if features.Storage.SkipVirtualNetworkLockingOnDestroy {
+		t.Errorf("expected storage.skip_virtual_network_locking_on_destroy to be false")
+	}
 }
 
 // TODO - helper functions to make setting up test date more easily so we can add more configuration coverage
@@ -302,6 +327,16 @@ func defaultFeaturesList() types.List {
 	})
 	recoveryServicesVaultsList, _ := basetypes.NewListValue(types.ObjectType{}.WithAttributeTypes(RecoveryServiceVaultsAttributes), []attr.Value{recoveryServicesVaults})
 
+	storage, _ := basetypes.NewObjectValueFrom(context.Background(), StorageAttributes, map[string]attr.Value{
+		"data_plane_access_on_read_default":          basetypes.NewBoolNull(),
+		"skip_key_retrieval":                         basetypes.NewBoolNull(),
+		"subnet_service_endpoint_validation_enabled": basetypes.NewBoolNull(),
+		"inherit_resource_group_tags":                basetypes.NewBoolNull(),
+		"ignored_tag_prefixes":                       basetypes.NewListNull(types.StringType),
+		"skip_virtual_network_locking_on_destroy":    basetypes.NewBoolNull(),
+	})
+	storageList, _ := basetypes.NewListValue(types.ObjectType{}.WithAttributeTypes(StorageAttributes), []attr.Value{storage})
+
 	fData, d := basetypes.NewObjectValue(FeaturesAttributes, map[string]attr.Value{
 		"api_management":             apiManagementList,
 		"app_configuration":          appConfigurationList,
@@ -319,6 +354,7 @@ func defaultFeaturesList() types.List {
 		"machine_learning":           machineLearningList,
 		"recovery_service":           recoveryServicesList,
 		"recovery_services_vaults":   recoveryServicesVaultsList,
+		"storage":                    storageList,
 	})
 
 	fmt.Printf("%+v", d)
@@ -34,6 +34,7 @@ type ProviderModel struct {
 	DisableCorrelationRequestId   types.Bool   `tfsdk:"disable_correlation_request_id"`
 	DisableTerraformPartnerId     types.Bool   `tfsdk:"disable_terraform_partner_id"`
 	StorageUseAzureAD             types.Bool   `tfsdk:"storage_use_azuread"`
+	StorageDomainSuffix           types.String `tfsdk:"storage_domain_suffix"`
 	Features                      types.List   `tfsdk:"features"`
 	SkipProviderRegistration      types.Bool   `tfsdk:"skip_provider_registration"` // TODO - Remove in 5.0
 	ResourceProviderRegistrations types.String `tfsdk:"resource_provider_registrations"`
@@ -57,6 +58,7 @@ type Features struct {
 	MachineLearning          types.List `tfsdk:"machine_learning"`
 	RecoveryService          types.List `tfsdk:"recovery_service"`
 	RecoveryServicesVaults   types.List `tfsdk:"recovery_services_vaults"`
+	Storage                  types.List `tfsdk:"storage"`
 }
 
 // FeaturesAttributes and the other block attribute vars are required for unit testing on the Load func
@@ -78,6 +80,7 @@ var FeaturesAttributes = map[string]attr.Type{
 	"machine_learning":           types.ListType{}.WithElementType(types.ObjectType{}.WithAttributeTypes(MachineLearningAttributes)),
 	"recovery_service":           types.ListType{}.WithElementType(types.ObjectType{}.WithAttributeTypes(RecoveryServiceAttributes)),
 	"recovery_services_vaults":   types.ListType{}.WithElementType(types.ObjectType{}.WithAttributeTypes(RecoveryServiceVaultsAttributes)),
+	"storage":                    types.ListType{}.WithElementType(types.ObjectType{}.WithAttributeTypes(StorageAttributes)),
 }
 
 type APIManagement struct {
@@ -245,3 +248,21 @@ type RecoveryServiceVaults struct {
 var RecoveryServiceVaultsAttributes = map[string]attr.Type{
 	"recover_soft_deleted_backup_protected_vm": types.BoolType,
 }
+
+type Storage struct {
+	DataPlaneAccessOnReadDefault           types.Bool `tfsdk:"data_plane_access_on_read_default"`
+	SkipKeyRetrieval                       types.Bool `tfsdk:"skip_key_retrieval"`
+	SubnetServiceEndpointValidationEnabled types.Bool `tfsdk:"subnet_service_endpoint_validation_enabled"`
+	InheritResourceGroupTags               types.Bool `tfsdk:"inherit_resource_group_tags"`
+	IgnoredTagPrefixes                     types.List `tfsdk:"ignored_tag_prefixes"`
+	SkipVirtualNetworkLockingOnDestroy     types.Bool `tfsdk:"skip_virtual_network_locking_on_destroy"`
+}
+
+var StorageAttributes = map[string]attr.Type{
+	"data_plane_access_on_read_default":          types.BoolType,
+	"skip_key_retrieval":                         types.BoolType,
+	"subnet_service_endpoint_validation_enabled": types.BoolType,
+	"inherit_resource_group_tags":                types.BoolType,
+	"ignored_tag_prefixes":                       types.ListType{ElemType: types.StringType},
+	"skip_virtual_network_locking_on_destroy":    types.BoolType,
+}
@@ -195,6 +195,11 @@ func (p *azureRmFrameworkProvider) Schema(_ context.Context, _ provider.SchemaRe
 				Description: "Should the AzureRM Provider use Azure AD Authentication when accessing the Storage Data Plane APIs?",
 			},
 
+			"storage_domain_suffix": schema.StringAttribute{
+				Optional:    true,
+				Description: "The Domain Suffix which should be used for Storage Accounts, overriding the value from the Cloud Environment. Only required for custom clouds where this can't be determined.",
+			},
+
 			"resource_provider_registrations": schema.StringAttribute{
 				Optional:    true,
 				Description: "The set of Resource Providers which should be automatically registered for the subscription.",
@@ -454,6 +459,31 @@ func (p *azureRmFrameworkProvider) Schema(_ context.Context, _ provider.SchemaRe
 								},
 							},
 						},
+						"storage": schema.ListNestedBlock{
+							NestedObject: schema.NestedBlockObject{
+								Attributes: map[string]schema.Attribute{
+									"data_plane_access_on_read_default": schema.BoolAttribute{
+										Optional: true,
+									},
+									"skip_key_retrieval": schema.BoolAttribute{
+										Optional: true,
+									},
+									"subnet_service_endpoint_validation_enabled": schema.BoolAttribute{
+										Optional: true,
+									},
+									"inherit_resource_group_tags": schema.BoolAttribute{
+										Optional: true,
+									},
+									"ignored_tag_prefixes": schema.ListAttribute{
+										ElementType: types.StringType,
+										Optional:    true,
+									},
+									"skip_virtual_network_locking_on_destroy": schema.BoolAttribute{
+										Optional: true,
+									},
+								},
+							},
+						},
 					},
 				},
 			},
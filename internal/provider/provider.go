@@ -7,6 +7,7 @@ import (
 	"context"
 	"fmt"
 	"os"
+	"regexp"
 	"strings"
 	"time"
 
@@ -23,6 +24,27 @@ import (
 	"github.com/hashicorp/terraform-provider-azurerm/utils"
 )
 
+var storageDomainSuffixRegex = regexp.MustCompile(`^([a-z0-9]([a-z0-9-]{0,61}[a-z0-9])?\.)+[a-z]{2,63}$`)
+
+// ValidateStorageDomainSuffix checks that storage_domain_suffix looks like a DNS suffix, e.g. `core.windows.net`,
+// rather than a URI or a hostname which includes the Storage Account name/service
+func ValidateStorageDomainSuffix(i interface{}, k string) ([]string, []error) {
+	v, ok := i.(string)
+	if !ok {
+		return nil, []error{fmt.Errorf("expected type of %q to be string", k)}
+	}
+
+	if v == "" {
+		return nil, nil
+	}
+
+	if !storageDomainSuffixRegex.MatchString(v) {
+		return nil, []error{fmt.Errorf("expected %q to be a domain suffix such as `core.windows.net` without a scheme, leading/trailing dots or a path, got %q", k, v)}
+	}
+
+	return nil, nil
+}
+
 func AzureProvider() *schema.Provider {
 	return azureProvider(false)
 }
@@ -361,6 +383,14 @@ func azureProvider(supportLegacyTestSuite bool) *schema.Provider {
 				DefaultFunc: schema.EnvDefaultFunc("ARM_STORAGE_USE_AZUREAD", false),
 				Description: "Should the AzureRM Provider use Azure AD Authentication when accessing the Storage Data Plane APIs?",
 			},
+
+			"storage_domain_suffix": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ValidateFunc: ValidateStorageDomainSuffix,
+				DefaultFunc:  schema.EnvDefaultFunc("ARM_STORAGE_DOMAIN_SUFFIX", ""),
+				Description:  "The Domain Suffix which should be used for Storage Accounts, overriding the value from the Cloud Environment. Only required for custom clouds where this can't be determined.",
+			},
 		},
 
 		DataSourcesMap: dataSources,
@@ -524,6 +554,7 @@ func buildClient(ctx context.Context, p *schema.Provider, d *schema.ResourceData
 		MetadataHost:                d.Get("metadata_host").(string),
 		PartnerID:                   d.Get("partner_id").(string),
 		RegisteredResourceProviders: requiredResourceProviders,
+		StorageDomainSuffix:         d.Get("storage_domain_suffix").(string),
 		StorageUseAzureAD:           d.Get("storage_use_azuread").(bool),
 		SubscriptionID:              d.Get("subscription_id").(string),
 		TerraformVersion:            p.TerraformVersion,
@@ -125,6 +125,62 @@ func TestProvider_counts(t *testing.T) {
 	log.Printf("Total:        %d", len(provider.ResourcesMap)+len(provider.DataSourcesMap))
 }
 
+func TestValidateStorageDomainSuffix(t *testing.T) {
+	cases := []struct {
+		Input string
+		Valid bool
+	}{
+		{
+			Input: "",
+			Valid: true,
+		},
+		{
+			Input: "core.windows.net",
+			Valid: true,
+		},
+		{
+			Input: "core.chinacloudapi.cn",
+			Valid: true,
+		},
+		{
+			Input: "storage.my-custom-cloud.example.com",
+			Valid: true,
+		},
+		{
+			Input: "localhost",
+			Valid: false,
+		},
+		{
+			Input: ".core.windows.net",
+			Valid: false,
+		},
+		{
+			Input: "core.windows.net.",
+			Valid: false,
+		},
+		{
+			Input: "https://core.windows.net",
+			Valid: false,
+		},
+		{
+			Input: "core.windows.net/path",
+			Valid: false,
+		},
+		{
+			Input: "Core.Windows.Net",
+			Valid: false,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Logf("[DEBUG] Testing Value %q", tc.Input)
+		_, errors := ValidateStorageDomainSuffix(tc.Input, "storage_domain_suffix")
+		if valid := len(errors) == 0; valid != tc.Valid {
+			t.Fatalf("expected %q to be valid %t, got %t", tc.Input, tc.Valid, valid)
+		}
+	}
+}
+
 func TestAccProvider_resourceProviders_legacy(t *testing.T) {
 	if os.Getenv("TF_ACC") == "" {
 		t.Skip("TF_ACC not set")
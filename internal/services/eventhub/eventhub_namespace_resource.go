@@ -268,6 +268,7 @@ func resourceEventHubNamespace() *pluginsdk.Resource {
 				return nil
 			}),
 			pluginsdk.CustomizeDiffShim(eventhubTLSVersionDiff),
+			pluginsdk.CustomizeDiffShim(eventhubAutoInflateDiff),
 		),
 	}
 
@@ -618,8 +619,9 @@ func resourceEventHubNamespaceRead(d *pluginsdk.ResourceData, meta interface{})
 	}
 
 	if model := keys.Model; model != nil {
-		d.Set("default_primary_connection_string_alias", model.AliasPrimaryConnectionString)
-		d.Set("default_secondary_connection_string_alias", model.AliasSecondaryConnectionString)
+		// the alias connection strings are only returned once a Disaster Recovery Config is associated with the namespace
+		d.Set("default_primary_connection_string_alias", pointer.From(model.AliasPrimaryConnectionString))
+		d.Set("default_secondary_connection_string_alias", pointer.From(model.AliasSecondaryConnectionString))
 		d.Set("default_primary_connection_string", model.PrimaryConnectionString)
 		d.Set("default_secondary_connection_string", model.SecondaryConnectionString)
 		d.Set("default_primary_key", model.PrimaryKey)
@@ -801,6 +803,27 @@ func eventhubTLSVersionDiff(ctx context.Context, d *pluginsdk.ResourceDiff, _ in
 	return
 }
 
+// eventhubAutoInflateDiff ensures `maximum_throughput_units` is only (and always) specified
+// alongside Auto Inflate, since the API rejects a ceiling of 0 when Auto Inflate is enabled
+// and ignores one when it isn't.
+func eventhubAutoInflateDiff(ctx context.Context, d *pluginsdk.ResourceDiff, _ interface{}) error {
+	if !d.NewValueKnown("auto_inflate_enabled") || !d.NewValueKnown("maximum_throughput_units") {
+		return nil
+	}
+
+	autoInflateEnabled := d.Get("auto_inflate_enabled").(bool)
+	maximumThroughputUnits := d.Get("maximum_throughput_units").(int)
+
+	if autoInflateEnabled && maximumThroughputUnits == 0 {
+		return fmt.Errorf("`maximum_throughput_units` must be set to a value between `1` and `40` when `auto_inflate_enabled` is `true`")
+	}
+	if !autoInflateEnabled && maximumThroughputUnits > 0 {
+		return fmt.Errorf("`maximum_throughput_units` can only be set when `auto_inflate_enabled` is `true`")
+	}
+
+	return nil
+}
+
 func eventHubNamespaceProvisioningStateRefreshFunc(ctx context.Context, client *namespaces.NamespacesClient, id namespaces.NamespaceId) pluginsdk.StateRefreshFunc {
 	return func() (interface{}, string, error) {
 		res, err := client.Get(ctx, id)
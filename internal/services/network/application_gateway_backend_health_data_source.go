@@ -0,0 +1,178 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package network
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/hashicorp/go-azure-helpers/lang/pointer"
+	"github.com/hashicorp/go-azure-sdk/resource-manager/network/2023-11-01/applicationgateways"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/clients"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/tf/pluginsdk"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/timeouts"
+)
+
+func dataSourceApplicationGatewayBackendHealth() *pluginsdk.Resource {
+	return &pluginsdk.Resource{
+		Read: dataSourceApplicationGatewayBackendHealthRead,
+
+		Timeouts: &pluginsdk.ResourceTimeout{
+			Read: pluginsdk.DefaultTimeout(10 * time.Minute),
+		},
+
+		Schema: map[string]*pluginsdk.Schema{
+			"application_gateway_id": {
+				Type:         pluginsdk.TypeString,
+				Required:     true,
+				ValidateFunc: applicationgateways.ValidateApplicationGatewayID,
+			},
+
+			"backend_address_pool": {
+				Type:     pluginsdk.TypeList,
+				Computed: true,
+				Elem: &pluginsdk.Resource{
+					Schema: map[string]*pluginsdk.Schema{
+						"id": {
+							Type:     pluginsdk.TypeString,
+							Computed: true,
+						},
+
+						"name": {
+							Type:     pluginsdk.TypeString,
+							Computed: true,
+						},
+
+						"backend_http_settings": {
+							Type:     pluginsdk.TypeList,
+							Computed: true,
+							Elem: &pluginsdk.Resource{
+								Schema: map[string]*pluginsdk.Schema{
+									"id": {
+										Type:     pluginsdk.TypeString,
+										Computed: true,
+									},
+
+									"name": {
+										Type:     pluginsdk.TypeString,
+										Computed: true,
+									},
+
+									"server": {
+										Type:     pluginsdk.TypeList,
+										Computed: true,
+										Elem: &pluginsdk.Resource{
+											Schema: map[string]*pluginsdk.Schema{
+												"address": {
+													Type:     pluginsdk.TypeString,
+													Computed: true,
+												},
+
+												"health": {
+													Type:     pluginsdk.TypeString,
+													Computed: true,
+												},
+
+												"health_probe_log": {
+													Type:     pluginsdk.TypeString,
+													Computed: true,
+												},
+											},
+										},
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func dataSourceApplicationGatewayBackendHealthRead(d *pluginsdk.ResourceData, meta interface{}) error {
+	client := meta.(*clients.Client).Network.ApplicationGateways
+	ctx, cancel := timeouts.ForRead(meta.(*clients.Client).StopContext, d)
+	defer cancel()
+
+	id, err := applicationgateways.ParseApplicationGatewayID(d.Get("application_gateway_id").(string))
+	if err != nil {
+		return err
+	}
+
+	result, err := client.BackendHealth(ctx, *id, applicationgateways.DefaultBackendHealthOperationOptions())
+	if err != nil {
+		return fmt.Errorf("retrieving Backend Health for %s: %+v", *id, err)
+	}
+	if err := result.Poller.PollUntilDone(ctx); err != nil {
+		return fmt.Errorf("waiting for the Backend Health of %s: %+v", *id, err)
+	}
+
+	var backendHealth applicationgateways.ApplicationGatewayBackendHealth
+	if err := result.Poller.FinalResult(&backendHealth); err != nil {
+		return fmt.Errorf("retrieving Backend Health for %s: %+v", *id, err)
+	}
+
+	d.SetId(id.ID())
+	d.Set("application_gateway_id", id.ID())
+
+	if err := d.Set("backend_address_pool", flattenApplicationGatewayBackendHealthPools(backendHealth.BackendAddressPools)); err != nil {
+		return fmt.Errorf("setting `backend_address_pool`: %+v", err)
+	}
+
+	return nil
+}
+
+func flattenApplicationGatewayBackendHealthPools(input *[]applicationgateways.ApplicationGatewayBackendHealthPool) []interface{} {
+	results := make([]interface{}, 0)
+	if input == nil {
+		return results
+	}
+
+	for _, pool := range *input {
+		poolId := ""
+		poolName := ""
+		if v := pool.BackendAddressPool; v != nil {
+			poolId = pointer.From(v.Id)
+			poolName = pointer.From(v.Name)
+		}
+
+		httpSettings := make([]interface{}, 0)
+		if pool.BackendHTTPSettingsCollection != nil {
+			for _, settings := range *pool.BackendHTTPSettingsCollection {
+				settingsId := ""
+				settingsName := ""
+				if v := settings.BackendHTTPSettings; v != nil {
+					settingsId = pointer.From(v.Id)
+					settingsName = pointer.From(v.Name)
+				}
+
+				servers := make([]interface{}, 0)
+				if settings.Servers != nil {
+					for _, server := range *settings.Servers {
+						servers = append(servers, map[string]interface{}{
+							"address":          pointer.From(server.Address),
+							"health":           string(pointer.From(server.Health)),
+							"health_probe_log": pointer.From(server.HealthProbeLog),
+						})
+					}
+				}
+
+				httpSettings = append(httpSettings, map[string]interface{}{
+					"id":     settingsId,
+					"name":   settingsName,
+					"server": servers,
+				})
+			}
+		}
+
+		results = append(results, map[string]interface{}{
+			"id":                    poolId,
+			"name":                  poolName,
+			"backend_http_settings": httpSettings,
+		})
+	}
+
+	return results
+}
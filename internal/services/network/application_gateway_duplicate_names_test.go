@@ -0,0 +1,49 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package network
+
+import (
+	"testing"
+)
+
+func TestApplicationGatewayFindDuplicateName(t *testing.T) {
+	testData := []struct {
+		name        string
+		input       []string
+		expectError bool
+	}{
+		{
+			name:        "no names",
+			input:       []string{},
+			expectError: false,
+		},
+		{
+			name:        "unique names",
+			input:       []string{"listener-http", "listener-https"},
+			expectError: false,
+		},
+		{
+			name:        "duplicate names",
+			input:       []string{"listener-http", "listener-https", "listener-http"},
+			expectError: true,
+		},
+		{
+			name:        "duplicate names differing by case",
+			input:       []string{"listener-http", "Listener-HTTP"},
+			expectError: true,
+		},
+	}
+
+	for _, v := range testData {
+		t.Logf("[DEBUG] Testing %q..", v.name)
+
+		err := findDuplicateName("http_listener", v.input)
+		if v.expectError && err == nil {
+			t.Fatalf("expected an error but didn't get one")
+		}
+		if !v.expectError && err != nil {
+			t.Fatalf("expected no error but got: %+v", err)
+		}
+	}
+}
@@ -0,0 +1,80 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package network
+
+import (
+	"testing"
+)
+
+func TestApplicationGatewayValidateFrontendIPConfigurations(t *testing.T) {
+	testData := []struct {
+		name        string
+		input       []applicationGatewayFrontendIPConfiguration
+		expectError bool
+	}{
+		{
+			name:        "no frontends",
+			input:       []applicationGatewayFrontendIPConfiguration{},
+			expectError: false,
+		},
+		{
+			name: "single public frontend",
+			input: []applicationGatewayFrontendIPConfiguration{
+				{name: "public", public: true},
+			},
+			expectError: false,
+		},
+		{
+			name: "single private frontend with a subnet",
+			input: []applicationGatewayFrontendIPConfiguration{
+				{name: "private", hasSubnet: true},
+			},
+			expectError: false,
+		},
+		{
+			name: "single private frontend without a subnet",
+			input: []applicationGatewayFrontendIPConfiguration{
+				{name: "private"},
+			},
+			expectError: true,
+		},
+		{
+			name: "public and private frontends",
+			input: []applicationGatewayFrontendIPConfiguration{
+				{name: "public", public: true},
+				{name: "private", hasSubnet: true},
+			},
+			expectError: false,
+		},
+		{
+			name: "two public frontends",
+			input: []applicationGatewayFrontendIPConfiguration{
+				{name: "public-1", public: true},
+				{name: "public-2", public: true},
+			},
+			expectError: true,
+		},
+		{
+			name: "two private frontends",
+			input: []applicationGatewayFrontendIPConfiguration{
+				{name: "public", public: true},
+				{name: "private-1", hasSubnet: true},
+				{name: "private-2", hasSubnet: true},
+			},
+			expectError: true,
+		},
+	}
+
+	for _, v := range testData {
+		t.Logf("[DEBUG] Testing %q..", v.name)
+
+		err := validateFrontendIPConfigurations(v.input)
+		if v.expectError && err == nil {
+			t.Fatalf("expected an error but didn't get one")
+		}
+		if !v.expectError && err != nil {
+			t.Fatalf("expected no error but got: %+v", err)
+		}
+	}
+}
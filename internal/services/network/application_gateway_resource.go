@@ -25,7 +25,9 @@ import (
 	"github.com/hashicorp/terraform-provider-azurerm/helpers/tf"
 	"github.com/hashicorp/terraform-provider-azurerm/helpers/validate"
 	"github.com/hashicorp/terraform-provider-azurerm/internal/clients"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/features"
 	keyVaultValidate "github.com/hashicorp/terraform-provider-azurerm/internal/services/keyvault/validate"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/services/network/migration"
 	"github.com/hashicorp/terraform-provider-azurerm/internal/services/network/parse"
 	networkValidate "github.com/hashicorp/terraform-provider-azurerm/internal/services/network/validate"
 	"github.com/hashicorp/terraform-provider-azurerm/internal/tf/pluginsdk"
@@ -52,7 +54,7 @@ func sslProfileSchema(computed bool) *pluginsdk.Schema {
 		Elem: &pluginsdk.Resource{
 			Schema: map[string]*pluginsdk.Schema{
 				"disabled_protocols": {
-					Type:     pluginsdk.TypeList,
+					Type:     pluginsdk.TypeSet,
 					Optional: true,
 					Elem: &pluginsdk.Schema{
 						Type: pluginsdk.TypeString,
@@ -105,7 +107,7 @@ func sslProfileSchema(computed bool) *pluginsdk.Schema {
 }
 
 func resourceApplicationGateway() *pluginsdk.Resource {
-	return &pluginsdk.Resource{
+	resource := &pluginsdk.Resource{
 		Create: resourceApplicationGatewayCreate,
 		Read:   resourceApplicationGatewayRead,
 		Update: resourceApplicationGatewayUpdate,
@@ -115,6 +117,11 @@ func resourceApplicationGateway() *pluginsdk.Resource {
 			return err
 		}),
 
+		SchemaVersion: 1,
+		StateUpgraders: pluginsdk.StateUpgrades(map[int]pluginsdk.StateUpgrade{
+			0: migration.ApplicationGatewayV0ToV1{},
+		}),
+
 		Timeouts: &pluginsdk.ResourceTimeout{
 			Create: pluginsdk.DefaultTimeout(90 * time.Minute),
 			Read:   pluginsdk.DefaultTimeout(5 * time.Minute),
@@ -902,15 +909,22 @@ func resourceApplicationGateway() *pluginsdk.Resource {
 			// lintignore:XS003
 			"ssl_policy": sslProfileSchema(true),
 
-			// TODO 4.0: change this from enable_* to *_enabled
-			"enable_http2": {
+			"http2_enabled": {
 				Type:     pluginsdk.TypeBool,
 				Optional: true,
+				Computed: !features.FourPointOhBeta(),
+				ConflictsWith: func() []string {
+					if !features.FourPointOhBeta() {
+						return []string{"enable_http2"}
+					}
+					return []string{}
+				}(),
 			},
 
 			"force_firewall_policy_association": {
 				Type:     pluginsdk.TypeBool,
 				Optional: true,
+				Default:  false,
 			},
 
 			// lintignore:S016,S023
@@ -1543,6 +1557,18 @@ func resourceApplicationGateway() *pluginsdk.Resource {
 
 		CustomizeDiff: pluginsdk.CustomizeDiffShim(applicationGatewayCustomizeDiff),
 	}
+
+	if !features.FourPointOhBeta() {
+		resource.Schema["enable_http2"] = &pluginsdk.Schema{
+			Type:          pluginsdk.TypeBool,
+			Optional:      true,
+			Computed:      true,
+			ConflictsWith: []string{"http2_enabled"},
+			Deprecated:    "The property `enable_http2` has been superseded by `http2_enabled` and will be removed in v4.0 of the AzureRM Provider.",
+		}
+	}
+
+	return resource
 }
 
 func resourceApplicationGatewayCreate(d *pluginsdk.ResourceData, meta interface{}) error {
@@ -1564,7 +1590,10 @@ func resourceApplicationGatewayCreate(d *pluginsdk.ResourceData, meta interface{
 		return tf.ImportAsExistsError("azurerm_application_gateway", id.ID())
 	}
 
-	enablehttp2 := d.Get("enable_http2").(bool)
+	enablehttp2 := d.Get("http2_enabled").(bool)
+	if v, ok := d.GetOk("enable_http2"); ok && !features.FourPointOhBeta() {
+		enablehttp2 = v.(bool)
+	}
 	t := d.Get("tags").(map[string]interface{})
 
 	// Gateway ID is needed to link sub-resources together in expand functions
@@ -1751,7 +1780,11 @@ func resourceApplicationGatewayUpdate(d *pluginsdk.ResourceData, meta interface{
 		payload.Properties = &applicationgateways.ApplicationGatewayPropertiesFormat{}
 	}
 
-	if d.HasChange("enable_http2") {
+	if d.HasChange("http2_enabled") {
+		payload.Properties.EnableHTTP2 = pointer.To(d.Get("http2_enabled").(bool))
+	}
+
+	if !features.FourPointOhBeta() && d.HasChange("enable_http2") {
 		payload.Properties.EnableHTTP2 = pointer.To(d.Get("enable_http2").(bool))
 	}
 
@@ -2042,7 +2075,10 @@ func resourceApplicationGatewayRead(d *pluginsdk.ResourceData, meta interface{})
 				return fmt.Errorf("setting `ssl_policy`: %+v", setErr)
 			}
 
-			d.Set("enable_http2", props.EnableHTTP2)
+			d.Set("http2_enabled", props.EnableHTTP2)
+			if !features.FourPointOhBeta() {
+				d.Set("enable_http2", props.EnableHTTP2)
+			}
 			d.Set("fips_enabled", props.EnableFips)
 			d.Set("force_firewall_policy_association", props.ForceFirewallPolicyAssociation)
 
@@ -2625,7 +2661,7 @@ func expandApplicationGatewaySslPolicy(vs []interface{}) *applicationgateways.Ap
 		v := vs[0].(map[string]interface{})
 		policyType := applicationgateways.ApplicationGatewaySslPolicyType(v["policy_type"].(string))
 
-		for _, policy := range v["disabled_protocols"].([]interface{}) {
+		for _, policy := range v["disabled_protocols"].(*pluginsdk.Set).List() {
 			disabledSSLProtocols = append(disabledSSLProtocols, applicationgateways.ApplicationGatewaySslProtocol(policy.(string)))
 		}
 
@@ -2668,9 +2704,9 @@ func flattenApplicationGatewaySslPolicy(input *applicationgateways.ApplicationGa
 	}
 
 	output := map[string]interface{}{}
-	output["policy_name"] = input.PolicyName
-	output["policy_type"] = input.PolicyType
-	output["min_protocol_version"] = input.MinProtocolVersion
+	output["policy_name"] = string(pointer.From(input.PolicyName))
+	output["policy_type"] = string(pointer.From(input.PolicyType))
+	output["min_protocol_version"] = string(pointer.From(input.MinProtocolVersion))
 
 	cipherSuites := make([]interface{}, 0)
 	if input.CipherSuites != nil {
@@ -4700,7 +4736,7 @@ func flattenApplicationGatewayCustomErrorConfigurations(input *[]applicationgate
 func checkSslPolicy(sslPolicy []interface{}) error {
 	if len(sslPolicy) > 0 && sslPolicy[0] != nil {
 		v := sslPolicy[0].(map[string]interface{})
-		disabledProtocols := v["disabled_protocols"].([]interface{})
+		disabledProtocols := v["disabled_protocols"].(*pluginsdk.Set).List()
 		policyType := v["policy_type"].(string)
 		if len(disabledProtocols) > 0 && policyType != "" {
 			return fmt.Errorf("setting disabled_protocols is not allowed when policy_type is defined")
@@ -4738,6 +4774,36 @@ func applicationGatewayCustomizeDiff(ctx context.Context, d *pluginsdk.ResourceD
 		}
 	}
 
+	if err := checkPrivateLinkConfigurationReferences(d); err != nil {
+		return err
+	}
+
+	if err := checkDuplicateNames(d); err != nil {
+		return err
+	}
+
+	if err := checkFrontendIPConfigurations(d); err != nil {
+		return err
+	}
+
+	// Mutual TLS (SSL Profiles with Trusted Client Certificates) is only available for V2 SKUs
+	if strings.EqualFold(tier, string(applicationgateways.ApplicationGatewayTierStandard)) || strings.EqualFold(tier, string(applicationgateways.ApplicationGatewayTierWAF)) {
+		if len(sslProfiles) > 0 {
+			return fmt.Errorf("`ssl_profile` can only be specified when the `sku` tier is %q or %q", string(applicationgateways.ApplicationGatewayTierStandardVTwo), string(applicationgateways.ApplicationGatewayTierWAFVTwo))
+		}
+		if len(d.Get("trusted_client_certificate").([]interface{})) > 0 {
+			return fmt.Errorf("`trusted_client_certificate` can only be specified when the `sku` tier is %q or %q", string(applicationgateways.ApplicationGatewayTierStandardVTwo), string(applicationgateways.ApplicationGatewayTierWAFVTwo))
+		}
+		// Availability Zones are only available for V2 SKUs
+		if d.Get("zones").(*schema.Set).Len() > 0 {
+			return fmt.Errorf("`zones` can only be specified when the `sku` tier is %q or %q", string(applicationgateways.ApplicationGatewayTierStandardVTwo), string(applicationgateways.ApplicationGatewayTierWAFVTwo))
+		}
+	}
+
+	if err := checkSkuNameForTier(d.Get("sku.0.name").(string), tier); err != nil {
+		return err
+	}
+
 	if hasCapacity {
 		if (strings.EqualFold(tier, string(applicationgateways.ApplicationGatewayTierStandard)) || strings.EqualFold(tier, string(applicationgateways.ApplicationGatewayTierWAF))) && (capacity.(int) < 1 || capacity.(int) > 32) {
 			return fmt.Errorf("The value '%d' exceeds the maximum capacity allowed for a %q V1 SKU, the %q SKU must have a capacity value between 1 and 32", capacity, tier, tier)
@@ -4751,6 +4817,227 @@ func applicationGatewayCustomizeDiff(ctx context.Context, d *pluginsdk.ResourceD
 	return nil
 }
 
+// applicationGatewaySkuNamesForTier are the SKU names which can be used with each SKU tier.
+var applicationGatewaySkuNamesForTier = map[applicationgateways.ApplicationGatewayTier][]applicationgateways.ApplicationGatewaySkuName{
+	applicationgateways.ApplicationGatewayTierStandard: {
+		applicationgateways.ApplicationGatewaySkuNameStandardSmall,
+		applicationgateways.ApplicationGatewaySkuNameStandardMedium,
+		applicationgateways.ApplicationGatewaySkuNameStandardLarge,
+	},
+	applicationgateways.ApplicationGatewayTierStandardVTwo: {
+		applicationgateways.ApplicationGatewaySkuNameStandardVTwo,
+	},
+	applicationgateways.ApplicationGatewayTierWAF: {
+		applicationgateways.ApplicationGatewaySkuNameWAFMedium,
+		applicationgateways.ApplicationGatewaySkuNameWAFLarge,
+	},
+	applicationgateways.ApplicationGatewayTierWAFVTwo: {
+		applicationgateways.ApplicationGatewaySkuNameWAFVTwo,
+	},
+}
+
+// checkSkuNameForTier ensures the `sku` name can be used with the `sku` tier, e.g. that the `WAF` tier uses one of the
+// `WAF_*` names - the name/tier are skipped when either isn't known yet.
+func checkSkuNameForTier(name, tier string) error {
+	if name == "" || tier == "" {
+		return nil
+	}
+
+	for skuTier, skuNames := range applicationGatewaySkuNamesForTier {
+		if !strings.EqualFold(tier, string(skuTier)) {
+			continue
+		}
+
+		validNames := make([]string, 0)
+		for _, skuName := range skuNames {
+			if strings.EqualFold(name, string(skuName)) {
+				return nil
+			}
+			validNames = append(validNames, fmt.Sprintf("%q", string(skuName)))
+		}
+
+		return fmt.Errorf("the `sku` name %q can't be used with the `sku` tier %q, the `name` must be one of %s when the `tier` is %q", name, tier, strings.Join(validNames, ", "), tier)
+	}
+
+	return nil
+}
+
+// checkPrivateLinkConfigurationReferences ensures that each `frontend_ip_configuration` referencing a Private Link
+// Configuration refers to a `private_link_configuration` defined on this Application Gateway.
+func checkPrivateLinkConfigurationReferences(d *pluginsdk.ResourceDiff) error {
+	config := d.GetRawConfig()
+	if config.IsNull() {
+		return nil
+	}
+	if v := config.GetAttr("private_link_configuration"); !v.IsWhollyKnown() {
+		return nil
+	}
+	if v := config.GetAttr("frontend_ip_configuration"); !v.IsWhollyKnown() {
+		return nil
+	}
+
+	privateLinkConfigurationNames := make(map[string]struct{})
+	for _, raw := range d.Get("private_link_configuration").(*pluginsdk.Set).List() {
+		if v, ok := raw.(map[string]interface{}); ok {
+			privateLinkConfigurationNames[v["name"].(string)] = struct{}{}
+		}
+	}
+
+	for _, raw := range d.Get("frontend_ip_configuration").([]interface{}) {
+		v, ok := raw.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		name := v["private_link_configuration_name"].(string)
+		if name == "" {
+			continue
+		}
+		if _, ok := privateLinkConfigurationNames[name]; !ok {
+			return fmt.Errorf("the `frontend_ip_configuration` %q references the Private Link Configuration %q which isn't defined within a `private_link_configuration` block", v["name"].(string), name)
+		}
+	}
+
+	return nil
+}
+
+// applicationGatewayNamedCollections are the blocks whose items are identified by their `name`, from which the ID
+// of the sub-resource is derived - as such each `name` must be unique within the block.
+var applicationGatewayNamedCollections = []string{
+	"authentication_certificate",
+	"backend_address_pool",
+	"backend_http_settings",
+	"frontend_ip_configuration",
+	"frontend_port",
+	"gateway_ip_configuration",
+	"http_listener",
+	"private_link_configuration",
+	"probe",
+	"redirect_configuration",
+	"request_routing_rule",
+	"rewrite_rule_set",
+	"ssl_certificate",
+	"ssl_profile",
+	"trusted_client_certificate",
+	"trusted_root_certificate",
+	"url_path_map",
+}
+
+// checkDuplicateNames ensures that the `name` of each item within the named collections is unique, rather than
+// surfacing a confusing error from the API when the derived IDs collide.
+func checkDuplicateNames(d *pluginsdk.ResourceDiff) error {
+	config := d.GetRawConfig()
+	if config.IsNull() {
+		return nil
+	}
+
+	for _, collection := range applicationGatewayNamedCollections {
+		v := config.GetAttr(collection)
+		if v.IsNull() || !v.IsKnown() {
+			continue
+		}
+
+		// names which aren't known until apply are skipped, since these can't be compared yet
+		names := make([]string, 0)
+		for it := v.ElementIterator(); it.Next(); {
+			_, item := it.Element()
+			if item.IsNull() || !item.IsKnown() {
+				continue
+			}
+			if name := item.GetAttr("name"); !name.IsNull() && name.IsKnown() {
+				names = append(names, name.AsString())
+			}
+		}
+
+		if err := findDuplicateName(collection, names); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func findDuplicateName(collection string, names []string) error {
+	existing := make(map[string]struct{})
+	for _, name := range names {
+		// the IDs of sub-resources are case-insensitive, so names differing only by casing also collide
+		key := strings.ToLower(name)
+		if _, ok := existing[key]; ok {
+			return fmt.Errorf("`%s` contains more than one block with the `name` %q - the `name` must be unique within `%s`", collection, name, collection)
+		}
+		existing[key] = struct{}{}
+	}
+
+	return nil
+}
+
+// applicationGatewayFrontendIPConfiguration is the subset of a `frontend_ip_configuration` block needed to determine
+// whether the combination of frontends is one the API will accept.
+type applicationGatewayFrontendIPConfiguration struct {
+	name      string
+	public    bool
+	hasSubnet bool
+}
+
+// checkFrontendIPConfigurations ensures that at most one public and one private `frontend_ip_configuration` are
+// specified and that each private frontend specifies a subnet, rather than failing with a generic error from the
+// API once the (lengthy) create has been attempted.
+func checkFrontendIPConfigurations(d *pluginsdk.ResourceDiff) error {
+	config := d.GetRawConfig()
+	if config.IsNull() {
+		return nil
+	}
+	v := config.GetAttr("frontend_ip_configuration")
+	if v.IsNull() || !v.IsKnown() {
+		return nil
+	}
+
+	// the ID of a Public IP / Subnet may not be known until apply, as such the presence of the field
+	// is used to determine the type of frontend rather than its value
+	frontends := make([]applicationGatewayFrontendIPConfiguration, 0)
+	for it := v.ElementIterator(); it.Next(); {
+		_, item := it.Element()
+		if item.IsNull() || !item.IsKnown() {
+			continue
+		}
+
+		frontend := applicationGatewayFrontendIPConfiguration{
+			public:    !item.GetAttr("public_ip_address_id").IsNull(),
+			hasSubnet: !item.GetAttr("subnet_id").IsNull(),
+		}
+		if name := item.GetAttr("name"); !name.IsNull() && name.IsKnown() {
+			frontend.name = name.AsString()
+		}
+		frontends = append(frontends, frontend)
+	}
+
+	return validateFrontendIPConfigurations(frontends)
+}
+
+func validateFrontendIPConfigurations(frontends []applicationGatewayFrontendIPConfiguration) error {
+	publicNames := make([]string, 0)
+	privateNames := make([]string, 0)
+	for _, frontend := range frontends {
+		if frontend.public {
+			publicNames = append(publicNames, fmt.Sprintf("%q", frontend.name))
+			continue
+		}
+
+		if !frontend.hasSubnet {
+			return fmt.Errorf("the private `frontend_ip_configuration` %q must specify a `subnet_id`, a frontend which doesn't specify a `public_ip_address_id` is private and requires a `subnet_id` for both `Static` and `Dynamic` allocation", frontend.name)
+		}
+		privateNames = append(privateNames, fmt.Sprintf("%q", frontend.name))
+	}
+
+	if len(publicNames) > 1 {
+		return fmt.Errorf("at most one public `frontend_ip_configuration` can be specified but got %d: %s", len(publicNames), strings.Join(publicNames, ", "))
+	}
+	if len(privateNames) > 1 {
+		return fmt.Errorf("at most one private `frontend_ip_configuration` can be specified but got %d: %s", len(privateNames), strings.Join(privateNames, ", "))
+	}
+
+	return nil
+}
+
 func applicationGatewayHttpListnerHash(v interface{}) int {
 	var buf bytes.Buffer
 
@@ -0,0 +1,90 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package network
+
+import (
+	"testing"
+)
+
+func TestApplicationGatewayCheckSkuNameForTier(t *testing.T) {
+	testData := []struct {
+		name        string
+		skuName     string
+		tier        string
+		expectError bool
+	}{
+		{
+			name:        "unknown values",
+			skuName:     "",
+			tier:        "",
+			expectError: false,
+		},
+		{
+			name:        "Standard tier with a Standard name",
+			skuName:     "Standard_Medium",
+			tier:        "Standard",
+			expectError: false,
+		},
+		{
+			name:        "Standard tier with a WAF name",
+			skuName:     "WAF_Medium",
+			tier:        "Standard",
+			expectError: true,
+		},
+		{
+			name:        "Standard tier with a V2 name",
+			skuName:     "Standard_v2",
+			tier:        "Standard",
+			expectError: true,
+		},
+		{
+			name:        "WAF tier with a WAF name",
+			skuName:     "WAF_Large",
+			tier:        "WAF",
+			expectError: false,
+		},
+		{
+			name:        "WAF tier with a Standard name",
+			skuName:     "Standard_Large",
+			tier:        "WAF",
+			expectError: true,
+		},
+		{
+			name:        "Standard_v2 tier with the Standard_v2 name",
+			skuName:     "Standard_v2",
+			tier:        "Standard_v2",
+			expectError: false,
+		},
+		{
+			name:        "Standard_v2 tier with the WAF_v2 name",
+			skuName:     "WAF_v2",
+			tier:        "Standard_v2",
+			expectError: true,
+		},
+		{
+			name:        "WAF_v2 tier with the WAF_v2 name",
+			skuName:     "WAF_v2",
+			tier:        "WAF_v2",
+			expectError: false,
+		},
+		{
+			name:        "WAF_v2 tier with a WAF V1 name",
+			skuName:     "WAF_Medium",
+			tier:        "WAF_v2",
+			expectError: true,
+		},
+	}
+
+	for _, v := range testData {
+		t.Logf("[DEBUG] Testing %q..", v.name)
+
+		err := checkSkuNameForTier(v.skuName, v.tier)
+		if v.expectError && err == nil {
+			t.Fatalf("expected an error but didn't get one")
+		}
+		if !v.expectError && err != nil {
+			t.Fatalf("expected no error but got: %+v", err)
+		}
+	}
+}
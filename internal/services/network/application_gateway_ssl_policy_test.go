@@ -0,0 +1,69 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package network
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/hashicorp/go-azure-helpers/lang/pointer"
+	"github.com/hashicorp/go-azure-sdk/resource-manager/network/2023-11-01/applicationgateways"
+)
+
+func TestFlattenApplicationGatewaySslPolicy(t *testing.T) {
+	testData := []struct {
+		name     string
+		input    *applicationgateways.ApplicationGatewaySslPolicy
+		expected []interface{}
+	}{
+		{
+			name:     "nil policy",
+			input:    nil,
+			expected: []interface{}{},
+		},
+		{
+			name: "predefined policy with nil disabled protocols",
+			input: &applicationgateways.ApplicationGatewaySslPolicy{
+				PolicyName: pointer.To(applicationgateways.ApplicationGatewaySslPolicyNameAppGwSslPolicyTwoZeroOneSevenZeroFourZeroOneS),
+				PolicyType: pointer.To(applicationgateways.ApplicationGatewaySslPolicyTypePredefined),
+			},
+			expected: []interface{}{
+				map[string]interface{}{
+					"policy_name":          "AppGwSslPolicy20170401S",
+					"policy_type":          "Predefined",
+					"min_protocol_version": "",
+					"cipher_suites":        []interface{}{},
+					"disabled_protocols":   []interface{}{},
+				},
+			},
+		},
+		{
+			name: "disabled protocols",
+			input: &applicationgateways.ApplicationGatewaySslPolicy{
+				DisabledSslProtocols: &[]applicationgateways.ApplicationGatewaySslProtocol{
+					applicationgateways.ApplicationGatewaySslProtocolTLSvOneZero,
+					applicationgateways.ApplicationGatewaySslProtocolTLSvOneOne,
+				},
+			},
+			expected: []interface{}{
+				map[string]interface{}{
+					"policy_name":          "",
+					"policy_type":          "",
+					"min_protocol_version": "",
+					"cipher_suites":        []interface{}{},
+					"disabled_protocols":   []interface{}{"TLSv1_0", "TLSv1_1"},
+				},
+			},
+		},
+	}
+
+	for _, v := range testData {
+		t.Logf("[DEBUG] Testing %q", v.name)
+
+		actual := flattenApplicationGatewaySslPolicy(v.input)
+		if !reflect.DeepEqual(actual, v.expected) {
+			t.Fatalf("Expected %+v but got %+v", v.expected, actual)
+		}
+	}
+}
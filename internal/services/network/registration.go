@@ -64,6 +64,7 @@ func (r Registration) Resources() []sdk.Resource {
 func (r Registration) SupportedDataSources() map[string]*pluginsdk.Resource {
 	return map[string]*pluginsdk.Resource{
 		"azurerm_application_gateway":                       dataSourceApplicationGateway(),
+		"azurerm_application_gateway_backend_health":        dataSourceApplicationGatewayBackendHealth(),
 		"azurerm_application_security_group":                dataSourceApplicationSecurityGroup(),
 		"azurerm_bastion_host":                              dataSourceBastionHost(),
 		"azurerm_express_route_circuit":                     dataSourceExpressRouteCircuit(),
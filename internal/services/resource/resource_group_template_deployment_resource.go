@@ -52,6 +52,17 @@ func resourceGroupTemplateDeploymentResource() *pluginsdk.Resource {
 				ValidateFunc: validate.TemplateDeploymentName,
 			},
 
+			"deployment_name": {
+				Type:     pluginsdk.TypeString,
+				Optional: true,
+				Computed: true,
+				ForceNew: true,
+				ValidateFunc: validation.All(
+					validate.TemplateDeploymentName,
+					validation.StringLenBetween(1, 64),
+				),
+			},
+
 			"resource_group_name": commonschema.ResourceGroupName(),
 
 			"deployment_mode": {
@@ -69,16 +80,20 @@ func resourceGroupTemplateDeploymentResource() *pluginsdk.Resource {
 				Computed: true,
 				ExactlyOneOf: []string{
 					"template_content",
+					"template_link",
 					"template_spec_version_id",
 				},
 				StateFunc: utils.NormalizeJson,
 			},
 
+			"template_link": templateDeploymentTemplateLinkSchema(),
+
 			"template_spec_version_id": {
 				Type:     pluginsdk.TypeString,
 				Optional: true,
 				ExactlyOneOf: []string{
 					"template_content",
+					"template_link",
 					"template_spec_version_id",
 				},
 				ValidateFunc: validate.TemplateSpecVersionID,
@@ -91,16 +106,57 @@ func resourceGroupTemplateDeploymentResource() *pluginsdk.Resource {
 				ValidateFunc: validation.StringInSlice(templateDeploymentDebugLevels, false),
 			},
 
+			"on_error_deployment": {
+				Type:     pluginsdk.TypeList,
+				Optional: true,
+				MaxItems: 1,
+				Elem: &pluginsdk.Resource{
+					Schema: map[string]*pluginsdk.Schema{
+						"type": {
+							Type:     pluginsdk.TypeString,
+							Required: true,
+							ValidateFunc: validation.StringInSlice([]string{
+								string(resources.OnErrorDeploymentTypeLastSuccessful),
+								string(resources.OnErrorDeploymentTypeSpecificDeployment),
+							}, false),
+						},
+
+						"deployment_name": {
+							Type:         pluginsdk.TypeString,
+							Optional:     true,
+							ValidateFunc: validate.TemplateDeploymentName,
+						},
+					},
+				},
+			},
+
 			"parameters_content": {
-				Type:      pluginsdk.TypeString,
-				Optional:  true,
-				Computed:  true,
+				Type:     pluginsdk.TypeString,
+				Optional: true,
+				Computed: true,
+				ConflictsWith: []string{
+					"parameters_link",
+				},
 				StateFunc: utils.NormalizeJson,
 			},
 
+			"parameters_content_secure": templateDeploymentParametersContentSecureSchema(),
+
+			"parameters_link": templateDeploymentParametersLinkSchema(),
+
 			"tags": tags.Schema(),
 
 			// Computed
+			"correlation_id": {
+				Type:     pluginsdk.TypeString,
+				Computed: true,
+			},
+
+			"duration": {
+				Type:     pluginsdk.TypeString,
+				Computed: true,
+			},
+
 			"output_content": {
 				Type:     pluginsdk.TypeString,
 				Computed: true,
@@ -113,6 +169,16 @@ func resourceGroupTemplateDeploymentResource() *pluginsdk.Resource {
 		// On a change to `template_content` or `parameters_content`, we'll set `output_content` to empty
 		// The adverse effect of this is that any change to `template_content` will also cause any resource referencing `output_content` to update
 		CustomizeDiff: func(ctx context.Context, d *pluginsdk.ResourceDiff, i interface{}) error {
+			if d.NewValueKnown("on_error_deployment.0.deployment_name") {
+				if err := validateTemplateDeploymentOnErrorDeployment(d.Get("on_error_deployment").([]interface{})); err != nil {
+					return err
+				}
+			}
+
+			if err := templateDeploymentParametersCustomizeDiff(ctx, d, i); err != nil {
+				return err
+			}
+
 			if d.HasChange("template_content") {
 				o, n := d.GetChange("template_content")
 
@@ -131,6 +197,10 @@ func resourceGroupTemplateDeploymentResource() *pluginsdk.Resource {
 				}
 			}
 
+			if d.HasChanges("template_link", "parameters_link", "parameters_content_secure") {
+				return d.SetNewComputed("output_content")
+			}
+
 			return nil
 		},
 	}
@@ -142,7 +212,7 @@ func resourceGroupTemplateDeploymentResourceCreate(d *pluginsdk.ResourceData, me
 	ctx, cancel := timeouts.ForCreate(meta.(*clients.Client).StopContext, d)
 	defer cancel()
 
-	id := parse.NewResourceGroupTemplateDeploymentID(subscriptionId, d.Get("resource_group_name").(string), d.Get("name").(string))
+	id := parse.NewResourceGroupTemplateDeploymentID(subscriptionId, d.Get("resource_group_name").(string), templateDeploymentName(d))
 
 	existing, err := client.Get(ctx, id.ResourceGroup, id.DeploymentName)
 	if err != nil {
@@ -156,32 +226,15 @@ func resourceGroupTemplateDeploymentResourceCreate(d *pluginsdk.ResourceData, me
 
 	deployment := resources.Deployment{
 		Properties: &resources.DeploymentProperties{
-			DebugSetting: expandTemplateDeploymentDebugSetting(d.Get("debug_level").(string)),
-			Mode:         resources.DeploymentMode(d.Get("deployment_mode").(string)),
+			DebugSetting:      expandTemplateDeploymentDebugSetting(d.Get("debug_level").(string)),
+			Mode:              resources.DeploymentMode(d.Get("deployment_mode").(string)),
+			OnErrorDeployment: expandTemplateDeploymentOnErrorDeployment(d.Get("on_error_deployment").([]interface{})),
 		},
 		Tags: tags.Expand(d.Get("tags").(map[string]interface{})),
 	}
 
-	if templateRaw, ok := d.GetOk("template_content"); ok {
-		template, err := expandTemplateDeploymentBody(templateRaw.(string))
-		if err != nil {
-			return fmt.Errorf("expanding `template_content`: %+v", err)
-		}
-		deployment.Properties.Template = template
-	}
-
-	if templateSpecVersionID, ok := d.GetOk("template_spec_version_id"); ok {
-		deployment.Properties.TemplateLink = &resources.TemplateLink{
-			ID: utils.String(templateSpecVersionID.(string)),
-		}
-	}
-
-	if v, ok := d.GetOk("parameters_content"); ok && v != "" {
-		parameters, err := expandTemplateDeploymentBody(v.(string))
-		if err != nil {
-			return fmt.Errorf("expanding `parameters_content`: %+v", err)
-		}
-		deployment.Properties.Parameters = parameters
+	if err := expandTemplateDeploymentContent(d, deployment.Properties); err != nil {
+		return err
 	}
 
 	log.Printf("[DEBUG] Running validation of Template Deployment %q (Resource Group %q)..", id.DeploymentName, id.ResourceGroup)
@@ -227,8 +280,9 @@ func resourceGroupTemplateDeploymentResourceUpdate(d *pluginsdk.ResourceData, me
 	// the API doesn't have a Patch operation, so we'll need to build one
 	deployment := resources.Deployment{
 		Properties: &resources.DeploymentProperties{
-			DebugSetting: template.Properties.DebugSetting,
-			Mode:         template.Properties.Mode,
+			DebugSetting:      template.Properties.DebugSetting,
+			Mode:              template.Properties.Mode,
+			OnErrorDeployment: expandTemplateDeploymentOnErrorDeployment(d.Get("on_error_deployment").([]interface{})),
 		},
 		Tags: template.Tags,
 	}
@@ -241,37 +295,16 @@ func resourceGroupTemplateDeploymentResourceUpdate(d *pluginsdk.ResourceData, me
 		deployment.Properties.Mode = resources.DeploymentMode(d.Get("deployment_mode").(string))
 	}
 
-	parameters, err := expandTemplateDeploymentBody(d.Get("parameters_content").(string))
-	if err != nil {
-		return fmt.Errorf("expanding `parameters_content`: %+v", err)
-	}
-	deployment.Properties.Parameters = parameters
-
-	if d.HasChange("template_content") {
-		templateContents, err := expandTemplateDeploymentBody(d.Get("template_content").(string))
-		if err != nil {
-			return fmt.Errorf("expanding `template_content`: %+v", err)
-		}
-
-		deployment.Properties.Template = templateContents
-	} else {
-		// retrieve the existing content and reuse that
+	exportTemplate := func() (interface{}, error) {
 		exportedTemplate, err := client.ExportTemplate(ctx, id.ResourceGroup, id.DeploymentName)
 		if err != nil {
-			return fmt.Errorf("retrieving Contents for Template Deployment %q (Resource Group %q): %+v", id.DeploymentName, id.ResourceGroup, err)
+			return nil, err
 		}
-
-		deployment.Properties.Template = exportedTemplate.Template
+		return exportedTemplate.Template, nil
 	}
 
-	if d.HasChange("template_spec_version_id") {
-		deployment.Properties.TemplateLink = &resources.TemplateLink{
-			ID: utils.String(d.Get("template_spec_version_id").(string)),
-		}
-
-		if d.Get("template_spec_version_id").(string) != "" {
-			deployment.Properties.Template = nil
-		}
+	if err := expandTemplateDeploymentContentForUpdate(d, deployment.Properties, exportTemplate); err != nil {
+		return err
 	}
 
 	if d.HasChange("tags") {
@@ -324,40 +357,25 @@ func resourceGroupTemplateDeploymentResourceRead(d *pluginsdk.ResourceData, meta
 		return fmt.Errorf("retrieving Template Content for Template Deployment %q (Resource Group %q): %+v", id.DeploymentName, id.ResourceGroup, err)
 	}
 
-	d.Set("name", id.DeploymentName)
+	// `name` only matches the name of the Deployment when `deployment_name` isn't specified, so is only set when importing
+	if d.Get("name").(string) == "" {
+		d.Set("name", id.DeploymentName)
+	}
+	d.Set("deployment_name", id.DeploymentName)
 	d.Set("resource_group_name", id.ResourceGroup)
 
 	if props := resp.Properties; props != nil {
 		d.Set("debug_level", flattenTemplateDeploymentDebugSetting(props.DebugSetting))
 		d.Set("deployment_mode", string(props.Mode))
 
-		filteredParams := filterOutTemplateDeploymentParameters(props.Parameters)
-		flattenedParams, err := flattenTemplateDeploymentBody(filteredParams)
-		if err != nil {
-			return fmt.Errorf("flattening `parameters_content`: %+v", err)
+		if err := d.Set("on_error_deployment", flattenTemplateDeploymentOnErrorDeployment(props.OnErrorDeployment)); err != nil {
+			return fmt.Errorf("setting `on_error_deployment`: %+v", err)
 		}
-		d.Set("parameters_content", flattenedParams)
-
-		flattenedOutputs, err := flattenTemplateDeploymentBody(props.Outputs)
-		if err != nil {
-			return fmt.Errorf("flattening `output_content`: %+v", err)
-		}
-		d.Set("output_content", flattenedOutputs)
-
-		templateLinkId := ""
-		if props.TemplateLink != nil {
-			if props.TemplateLink.ID != nil {
-				templateLinkId = *props.TemplateLink.ID
-			}
-		}
-		d.Set("template_spec_version_id", templateLinkId)
 	}
 
-	flattenedTemplate, err := flattenTemplateDeploymentBody(templateContents.Template)
-	if err != nil {
-		return fmt.Errorf("flattening `template_content`: %+v", err)
+	if err := flattenTemplateDeploymentContent(d, resp.Properties, templateContents.Template); err != nil {
+		return err
 	}
-	d.Set("template_content", flattenedTemplate)
 
 	return tags.FlattenAndSet(d, resp.Tags)
 }
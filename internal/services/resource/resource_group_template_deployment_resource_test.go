@@ -27,6 +27,8 @@ func TestAccResourceGroupTemplateDeployment_empty(t *testing.T) {
 			Config: r.emptyConfig(data, "Complete"),
 			Check: acceptance.ComposeTestCheckFunc(
 				check.That(data.ResourceName).ExistsInAzure(r),
+				check.That(data.ResourceName).Key("correlation_id").Exists(),
+				check.That(data.ResourceName).Key("duration").Exists(),
 			),
 		},
 		data.ImportStep(),
@@ -64,6 +66,22 @@ func TestAccResourceGroupTemplateDeployment_incremental(t *testing.T) {
 	})
 }
 
+func TestAccResourceGroupTemplateDeployment_deploymentName(t *testing.T) {
+	data := acceptance.BuildTestData(t, "azurerm_resource_group_template_deployment", "test")
+	r := ResourceGroupTemplateDeploymentResource{}
+
+	data.ResourceTest(t, r, []acceptance.TestStep{
+		{
+			Config: r.deploymentNameConfig(data),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+				check.That(data.ResourceName).Key("deployment_name").HasValue(fmt.Sprintf("acctest-%d", data.RandomInteger)),
+			),
+		},
+		data.ImportStep("name"),
+	})
+}
+
 func TestAccResourceGroupTemplateDeployment_singleItemIncorrectCasing(t *testing.T) {
 	data := acceptance.BuildTestData(t, "azurerm_resource_group_template_deployment", "test")
 	r := ResourceGroupTemplateDeploymentResource{}
@@ -142,6 +160,29 @@ func TestAccResourceGroupTemplateDeployment_singleItemUpdatingParams(t *testing.
 	})
 }
 
+func TestAccResourceGroupTemplateDeployment_secureParameters(t *testing.T) {
+	data := acceptance.BuildTestData(t, "azurerm_resource_group_template_deployment", "test")
+	r := ResourceGroupTemplateDeploymentResource{}
+
+	data.ResourceTest(t, r, []acceptance.TestStep{
+		{
+			Config: r.secureParametersConfig(data, "first-secret"),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+				check.That(data.ResourceName).Key("parameters_content").HasValue("{\"someParam\":{\"value\":\"first\"}}"),
+			),
+		},
+		data.ImportStep("parameters_content_secure"),
+		{
+			Config: r.secureParametersConfig(data, "second-secret"),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+			),
+		},
+		data.ImportStep("parameters_content_secure"),
+	})
+}
+
 func TestAccResourceGroupTemplateDeployment_singleItemUpdatingTemplate(t *testing.T) {
 	data := acceptance.BuildTestData(t, "azurerm_resource_group_template_deployment", "test")
 	r := ResourceGroupTemplateDeploymentResource{}
@@ -180,6 +221,45 @@ func TestAccResourceGroupTemplateDeployment_withOutputs(t *testing.T) {
 	})
 }
 
+func TestAccResourceGroupTemplateDeployment_onErrorDeployment(t *testing.T) {
+	data := acceptance.BuildTestData(t, "azurerm_resource_group_template_deployment", "test")
+	r := ResourceGroupTemplateDeploymentResource{}
+
+	data.ResourceTest(t, r, []acceptance.TestStep{
+		{
+			Config: r.emptyConfig(data, "Incremental"),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+			),
+		},
+		data.ImportStep(),
+		{
+			Config: r.onErrorDeploymentLastSuccessfulConfig(data),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+				check.That(data.ResourceName).Key("on_error_deployment.0.type").HasValue("LastSuccessful"),
+			),
+		},
+		data.ImportStep(),
+		{
+			Config: r.onErrorDeploymentSpecificDeploymentConfig(data),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+				check.That(data.ResourceName).Key("on_error_deployment.0.type").HasValue("SpecificDeployment"),
+				check.That(data.ResourceName).Key("on_error_deployment.0.deployment_name").HasValue("acctest"),
+			),
+		},
+		data.ImportStep(),
+		{
+			Config: r.emptyConfig(data, "Incremental"),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+			),
+		},
+		data.ImportStep(),
+	})
+}
+
 func TestAccResourceGroupTemplateDeployment_multipleItems(t *testing.T) {
 	data := acceptance.BuildTestData(t, "azurerm_resource_group_template_deployment", "test")
 	r := ResourceGroupTemplateDeploymentResource{}
@@ -314,6 +394,22 @@ func TestAccResourceGroupTemplateDeployment_outputReference(t *testing.T) {
 	})
 }
 
+func TestAccResourceGroupTemplateDeployment_templateLinkAndParametersLink(t *testing.T) {
+	data := acceptance.BuildTestData(t, "azurerm_resource_group_template_deployment", "test")
+	r := ResourceGroupTemplateDeploymentResource{}
+
+	data.ResourceTest(t, r, []acceptance.TestStep{
+		{
+			Config: r.withTemplateLinkAndParametersLinkConfig(data),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+				check.That(data.ResourceName).Key("output_content").HasValue("{\"testOutput\":{\"type\":\"String\",\"value\":\"some-value\"}}"),
+			),
+		},
+		data.ImportStep("template_link.0.query_string", "parameters_link.0.query_string"),
+	})
+}
+
 func (t ResourceGroupTemplateDeploymentResource) Exists(ctx context.Context, clients *clients.Client, state *pluginsdk.InstanceState) (*bool, error) {
 	id, err := parse.ResourceGroupTemplateDeploymentID(state.ID)
 	if err != nil {
@@ -357,6 +453,103 @@ TEMPLATE
 `, data.RandomInteger, data.Locations.Primary, deploymentMode)
 }
 
+func (ResourceGroupTemplateDeploymentResource) deploymentNameConfig(data acceptance.TestData) string {
+	return fmt.Sprintf(`
+provider "azurerm" {
+  features {}
+}
+
+resource "azurerm_resource_group" "test" {
+  name     = "acctestRG-%[1]d"
+  location = %[2]q
+}
+
+resource "azurerm_resource_group_template_deployment" "test" {
+  name                = "acctest-a-much-longer-name-which-exceeds-the-deployment-name-limit-%[1]d"
+  deployment_name     = "acctest-%[1]d"
+  resource_group_name = azurerm_resource_group.test.name
+  deployment_mode     = "Incremental"
+
+  template_content = <<TEMPLATE
+{
+  "$schema": "https://schema.management.azure.com/schemas/2015-01-01/deploymentTemplate.json#",
+  "contentVersion": "1.0.0.0",
+  "parameters": {},
+  "variables": {},
+  "resources": []
+}
+TEMPLATE
+}
+`, data.RandomInteger, data.Locations.Primary)
+}
+
+func (ResourceGroupTemplateDeploymentResource) onErrorDeploymentLastSuccessfulConfig(data acceptance.TestData) string {
+	return fmt.Sprintf(`
+provider "azurerm" {
+  features {}
+}
+
+resource "azurerm_resource_group" "test" {
+  name     = "acctestRG-%d"
+  location = %q
+}
+
+resource "azurerm_resource_group_template_deployment" "test" {
+  name                = "acctest"
+  resource_group_name = azurerm_resource_group.test.name
+  deployment_mode     = "Incremental"
+
+  on_error_deployment {
+    type = "LastSuccessful"
+  }
+
+  template_content = <<TEMPLATE
+{
+  "$schema": "https://schema.management.azure.com/schemas/2015-01-01/deploymentTemplate.json#",
+  "contentVersion": "1.0.0.0",
+  "parameters": {},
+  "variables": {},
+  "resources": []
+}
+TEMPLATE
+}
+`, data.RandomInteger, data.Locations.Primary)
+}
+
+func (ResourceGroupTemplateDeploymentResource) onErrorDeploymentSpecificDeploymentConfig(data acceptance.TestData) string {
+	return fmt.Sprintf(`
+provider "azurerm" {
+  features {}
+}
+
+resource "azurerm_resource_group" "test" {
+  name     = "acctestRG-%d"
+  location = %q
+}
+
+resource "azurerm_resource_group_template_deployment" "test" {
+  name                = "acctest"
+  resource_group_name = azurerm_resource_group.test.name
+  deployment_mode     = "Incremental"
+
+  on_error_deployment {
+    type            = "SpecificDeployment"
+    deployment_name = "acctest"
+  }
+
+  template_content = <<TEMPLATE
+{
+  "$schema": "https://schema.management.azure.com/schemas/2015-01-01/deploymentTemplate.json#",
+  "contentVersion": "1.0.0.0",
+  "parameters": {},
+  "variables": {},
+  "resources": []
+}
+TEMPLATE
+}
+`, data.RandomInteger, data.Locations.Primary)
+}
+
 func (ResourceGroupTemplateDeploymentResource) templateSpecVersionConfigEmpty(data acceptance.TestData) string {
 	return fmt.Sprintf(`
 provider "azurerm" {
@@ -681,6 +874,54 @@ PARAM
 `, data.RandomInteger, data.Locations.Primary, value)
 }
 
+func (ResourceGroupTemplateDeploymentResource) secureParametersConfig(data acceptance.TestData, secret string) string {
+	return fmt.Sprintf(`
+provider "azurerm" {
+  features {}
+}
+
+resource "azurerm_resource_group" "test" {
+  name     = "acctestRG-%d"
+  location = %q
+}
+
+resource "azurerm_resource_group_template_deployment" "test" {
+  name                = "acctest"
+  resource_group_name = azurerm_resource_group.test.name
+  deployment_mode     = "Complete"
+
+  template_content = <<TEMPLATE
+{
+  "$schema": "https://schema.management.azure.com/schemas/2015-01-01/deploymentTemplate.json#",
+  "contentVersion": "1.0.0.0",
+  "parameters": {
+    "someParam": {
+      "type": "String"
+    },
+    "secretParam": {
+      "type": "SecureString"
+    }
+  },
+  "variables": {},
+  "resources": []
+}
+TEMPLATE
+
+  parameters_content = jsonencode({
+    someParam = {
+      value = "first"
+    }
+  })
+
+  parameters_content_secure = jsonencode({
+    secretParam = {
+      value = %q
+    }
+  })
+}
+`, data.RandomInteger, data.Locations.Primary, secret)
+}
+
 func (ResourceGroupTemplateDeploymentResource) singleItemWithPublicIPConfig(data acceptance.TestData, tagValue string) string {
 	return fmt.Sprintf(`
 provider "azurerm" {
@@ -1121,5 +1362,111 @@ resource "azurerm_resource_group" "test2" {
 
 
 
+`, data.RandomInteger, data.Locations.Primary, data.RandomString)
+}
+
+func (ResourceGroupTemplateDeploymentResource) withTemplateLinkAndParametersLinkConfig(data acceptance.TestData) string {
+	return fmt.Sprintf(`
+provider "azurerm" {
+  features {}
+}
+
+resource "azurerm_resource_group" "test" {
+  name     = "acctestRG-%d"
+  location = %q
+}
+
+resource "azurerm_storage_account" "test" {
+  name                     = "acctestsa%s"
+  resource_group_name      = azurerm_resource_group.test.name
+  location                 = azurerm_resource_group.test.location
+  account_tier             = "Standard"
+  account_replication_type = "LRS"
+}
+
+resource "azurerm_storage_container" "test" {
+  name                  = "templates"
+  storage_account_name  = azurerm_storage_account.test.name
+  container_access_type = "private"
+}
+
+resource "azurerm_storage_blob" "template" {
+  name                   = "azuredeploy.json"
+  storage_account_name   = azurerm_storage_account.test.name
+  storage_container_name = azurerm_storage_container.test.name
+  type                   = "Block"
+  source_content         = <<TEMPLATE
+{
+  "$schema": "https://schema.management.azure.com/schemas/2015-01-01/deploymentTemplate.json#",
+  "contentVersion": "1.0.0.0",
+  "parameters": {
+    "someParam": {
+      "type": "String"
+    }
+  },
+  "variables": {},
+  "resources": [],
+  "outputs": {
+    "testOutput": {
+      "type": "String",
+      "value": "[parameters('someParam')]"
+    }
+  }
+}
+TEMPLATE
+}
+
+resource "azurerm_storage_blob" "parameters" {
+  name                   = "azuredeploy.parameters.json"
+  storage_account_name   = azurerm_storage_account.test.name
+  storage_container_name = azurerm_storage_container.test.name
+  type                   = "Block"
+  source_content         = <<PARAM
+{
+  "$schema": "https://schema.management.azure.com/schemas/2015-01-01/deploymentParameters.json#",
+  "contentVersion": "1.0.0.0",
+  "parameters": {
+    "someParam": {
+      "value": "some-value"
+    }
+  }
+}
+PARAM
+}
+
+data "azurerm_storage_account_blob_container_sas" "test" {
+  connection_string = azurerm_storage_account.test.primary_connection_string
+  container_name    = azurerm_storage_container.test.name
+  https_only        = true
+
+  start  = "2023-01-01"
+  expiry = "2050-01-01"
+
+  permissions {
+    read   = true
+    add    = false
+    create = false
+    write  = false
+    delete = false
+    list   = false
+  }
+}
+
+resource "azurerm_resource_group_template_deployment" "test" {
+  name                = "acctest"
+  resource_group_name = azurerm_resource_group.test.name
+  deployment_mode     = "Incremental"
+
+  template_link {
+    uri             = azurerm_storage_blob.template.url
+    content_version = "1.0.0.0"
+    query_string    = data.azurerm_storage_account_blob_container_sas.test.sas
+  }
+
+  parameters_link {
+    uri          = azurerm_storage_blob.parameters.url
+    query_string = data.azurerm_storage_account_blob_container_sas.test.sas
+  }
+}
 `, data.RandomInteger, data.Locations.Primary, data.RandomString)
 }
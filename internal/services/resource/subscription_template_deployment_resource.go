@@ -52,6 +52,17 @@ func subscriptionTemplateDeploymentResource() *pluginsdk.Resource {
 				ValidateFunc: validate.TemplateDeploymentName,
 			},
 
+			"deployment_name": {
+				Type:     pluginsdk.TypeString,
+				Optional: true,
+				Computed: true,
+				ForceNew: true,
+				ValidateFunc: validation.All(
+					validate.TemplateDeploymentName,
+					validation.StringLenBetween(1, 64),
+				),
+			},
+
 			"location": commonschema.Location(),
 
 			"template_content": {
@@ -60,16 +71,20 @@ func subscriptionTemplateDeploymentResource() *pluginsdk.Resource {
 				Computed: true,
 				ExactlyOneOf: []string{
 					"template_content",
+					"template_link",
 					"template_spec_version_id",
 				},
 				StateFunc: utils.NormalizeJson,
 			},
 
+			"template_link": templateDeploymentTemplateLinkSchema(),
+
 			"template_spec_version_id": {
 				Type:     pluginsdk.TypeString,
 				Optional: true,
 				ExactlyOneOf: []string{
 					"template_content",
+					"template_link",
 					"template_spec_version_id",
 				},
 				ValidateFunc: validate.TemplateSpecVersionID,
@@ -83,15 +98,32 @@ func subscriptionTemplateDeploymentResource() *pluginsdk.Resource {
 			},
 
 			"parameters_content": {
-				Type:      pluginsdk.TypeString,
-				Optional:  true,
-				Computed:  true,
+				Type:     pluginsdk.TypeString,
+				Optional: true,
+				Computed: true,
+				ConflictsWith: []string{
+					"parameters_link",
+				},
 				StateFunc: utils.NormalizeJson,
 			},
 
+			"parameters_content_secure": templateDeploymentParametersContentSecureSchema(),
+
+			"parameters_link": templateDeploymentParametersLinkSchema(),
+
 			"tags": tags.Schema(),
 
 			// Computed
+			"correlation_id": {
+				Type:     pluginsdk.TypeString,
+				Computed: true,
+			},
+
+			"duration": {
+				Type:     pluginsdk.TypeString,
+				Computed: true,
+			},
+
 			"output_content": {
 				Type:     pluginsdk.TypeString,
 				Computed: true,
@@ -99,6 +131,8 @@ func subscriptionTemplateDeploymentResource() *pluginsdk.Resource {
 				// parsing the JSON using `jsondecode` allows the users to interact with/map objects as required
 			},
 		},
+
+		CustomizeDiff: pluginsdk.CustomizeDiffShim(templateDeploymentParametersCustomizeDiff),
 	}
 }
 
@@ -108,7 +142,7 @@ func subscriptionTemplateDeploymentResourceCreate(d *pluginsdk.ResourceData, met
 	ctx, cancel := timeouts.ForCreate(meta.(*clients.Client).StopContext, d)
 	defer cancel()
 
-	id := parse.NewSubscriptionTemplateDeploymentID(subscriptionId, d.Get("name").(string))
+	id := parse.NewSubscriptionTemplateDeploymentID(subscriptionId, templateDeploymentName(d))
 
 	existing, err := client.GetAtSubscriptionScope(ctx, id.DeploymentName)
 	if err != nil {
@@ -129,26 +163,8 @@ func subscriptionTemplateDeploymentResourceCreate(d *pluginsdk.ResourceData, met
 		Tags: tags.Expand(d.Get("tags").(map[string]interface{})),
 	}
 
-	if templateRaw, ok := d.GetOk("template_content"); ok {
-		template, err := expandTemplateDeploymentBody(templateRaw.(string))
-		if err != nil {
-			return fmt.Errorf("expanding `template_content`: %+v", err)
-		}
-		deployment.Properties.Template = template
-	}
-
-	if templateSpecVersionID, ok := d.GetOk("template_spec_version_id"); ok {
-		deployment.Properties.TemplateLink = &resources.TemplateLink{
-			ID: utils.String(templateSpecVersionID.(string)),
-		}
-	}
-
-	if v, ok := d.GetOk("parameters_content"); ok && v != "" {
-		parameters, err := expandTemplateDeploymentBody(v.(string))
-		if err != nil {
-			return fmt.Errorf("expanding `parameters_content`: %+v", err)
-		}
-		deployment.Properties.Parameters = parameters
+	if err := expandTemplateDeploymentContent(d, deployment.Properties); err != nil {
+		return err
 	}
 
 	log.Printf("[DEBUG] Running validation of Subscription Template Deployment %q..", id.DeploymentName)
@@ -205,37 +221,16 @@ func subscriptionTemplateDeploymentResourceUpdate(d *pluginsdk.ResourceData, met
 		deployment.Properties.DebugSetting = expandTemplateDeploymentDebugSetting(d.Get("debug_level").(string))
 	}
 
-	parameters, err := expandTemplateDeploymentBody(d.Get("parameters_content").(string))
-	if err != nil {
-		return fmt.Errorf("expanding `parameters_content`: %+v", err)
-	}
-	deployment.Properties.Parameters = parameters
-
-	if d.HasChange("template_content") {
-		templateContents, err := expandTemplateDeploymentBody(d.Get("template_content").(string))
-		if err != nil {
-			return fmt.Errorf("expanding `template_content`: %+v", err)
-		}
-
-		deployment.Properties.Template = templateContents
-	} else {
-		// retrieve the existing content and reuse that
+	exportTemplate := func() (interface{}, error) {
 		exportedTemplate, err := client.ExportTemplateAtSubscriptionScope(ctx, id.DeploymentName)
 		if err != nil {
-			return fmt.Errorf("retrieving Contents for Subscription Template Deployment %q: %+v", id.DeploymentName, err)
+			return nil, err
 		}
-
-		deployment.Properties.Template = exportedTemplate.Template
+		return exportedTemplate.Template, nil
 	}
 
-	if d.HasChange("template_spec_version_id") {
-		deployment.Properties.TemplateLink = &resources.TemplateLink{
-			ID: utils.String(d.Get("template_spec_version_id").(string)),
-		}
-
-		if d.Get("template_spec_version_id").(string) != "" {
-			deployment.Properties.Template = nil
-		}
+	if err := expandTemplateDeploymentContentForUpdate(d, deployment.Properties, exportTemplate); err != nil {
+		return err
 	}
 
 	if d.HasChange("tags") {
@@ -288,39 +283,20 @@ func subscriptionTemplateDeploymentResourceRead(d *pluginsdk.ResourceData, meta
 		return fmt.Errorf("retrieving Template Content for Subscription Template Deployment %q: %+v", id.DeploymentName, err)
 	}
 
-	d.Set("name", id.DeploymentName)
+	// `name` only matches the name of the Deployment when `deployment_name` isn't specified, so is only set when importing
+	if d.Get("name").(string) == "" {
+		d.Set("name", id.DeploymentName)
+	}
+	d.Set("deployment_name", id.DeploymentName)
 	d.Set("location", location.NormalizeNilable(resp.Location))
 
 	if props := resp.Properties; props != nil {
 		d.Set("debug_level", flattenTemplateDeploymentDebugSetting(props.DebugSetting))
-
-		filteredParams := filterOutTemplateDeploymentParameters(props.Parameters)
-		flattenedParams, err := flattenTemplateDeploymentBody(filteredParams)
-		if err != nil {
-			return fmt.Errorf("flattening `parameters_content`: %+v", err)
-		}
-		d.Set("parameters_content", flattenedParams)
-
-		flattenedOutputs, err := flattenTemplateDeploymentBody(props.Outputs)
-		if err != nil {
-			return fmt.Errorf("flattening `output_content`: %+v", err)
-		}
-		d.Set("output_content", flattenedOutputs)
-
-		templateLinkId := ""
-		if props.TemplateLink != nil {
-			if props.TemplateLink.ID != nil {
-				templateLinkId = *props.TemplateLink.ID
-			}
-		}
-		d.Set("template_spec_version_id", templateLinkId)
 	}
 
-	flattenedTemplate, err := flattenTemplateDeploymentBody(templateContents.Template)
-	if err != nil {
-		return fmt.Errorf("flattening `template_content`: %+v", err)
+	if err := flattenTemplateDeploymentContent(d, resp.Properties, templateContents.Template); err != nil {
+		return err
 	}
-	d.Set("template_content", flattenedTemplate)
 
 	return tags.FlattenAndSet(d, resp.Tags)
 }
@@ -5,19 +5,25 @@ package resource
 
 import (
 	"context"
+	"crypto/sha1"
+	"encoding/hex"
 	"encoding/json"
 	"fmt"
 	"log"
 	"net/http"
 	"regexp"
+	"sort"
 	"strings"
 	"time"
 
 	"github.com/Azure/azure-sdk-for-go/services/resources/mgmt/2020-06-01/resources" // nolint: staticcheck
+	"github.com/hashicorp/go-azure-helpers/lang/pointer"
 	"github.com/hashicorp/go-azure-sdk/resource-manager/resources/2022-09-01/providers"
 	"github.com/hashicorp/terraform-provider-azurerm/helpers/azure"
 	"github.com/hashicorp/terraform-provider-azurerm/internal/services/resource/client"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/services/resource/validate"
 	"github.com/hashicorp/terraform-provider-azurerm/internal/tf/pluginsdk"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/tf/validation"
 	"github.com/hashicorp/terraform-provider-azurerm/utils"
 )
 
@@ -37,6 +43,15 @@ var templateDeploymentDebugLevels = []string{
 	string(debugLevelRequestContentResponseContent),
 }
 
+// templateDeploymentName returns the name of the ARM Deployment, which is `deployment_name` when specified and `name` otherwise
+func templateDeploymentName(d *pluginsdk.ResourceData) string {
+	if v := d.Get("deployment_name").(string); v != "" {
+		return v
+	}
+
+	return d.Get("name").(string)
+}
+
 func expandTemplateDeploymentDebugSetting(debugLevel string) *resources.DebugSetting {
 	if debugLevel == "" {
 		return &resources.DebugSetting{
@@ -63,6 +78,558 @@ func flattenTemplateDeploymentDebugSetting(input *resources.DebugSetting) string
 	return ""
 }
 
+func expandTemplateDeploymentOnErrorDeployment(input []interface{}) *resources.OnErrorDeployment {
+	if len(input) == 0 || input[0] == nil {
+		return nil
+	}
+
+	v := input[0].(map[string]interface{})
+	output := &resources.OnErrorDeployment{
+		Type: resources.OnErrorDeploymentType(v["type"].(string)),
+	}
+
+	if deploymentName := v["deployment_name"].(string); deploymentName != "" {
+		output.DeploymentName = utils.String(deploymentName)
+	}
+
+	return output
+}
+
+func flattenTemplateDeploymentOnErrorDeployment(input *resources.OnErrorDeploymentExtended) []interface{} {
+	if input == nil || input.Type == "" {
+		return []interface{}{}
+	}
+
+	deploymentName := ""
+	if input.DeploymentName != nil {
+		deploymentName = *input.DeploymentName
+	}
+
+	return []interface{}{
+		map[string]interface{}{
+			"type":            string(input.Type),
+			"deployment_name": deploymentName,
+		},
+	}
+}
+
+// validateTemplateDeploymentOnErrorDeployment ensures a `deployment_name` is only (and always) specified
+// when rolling back to a specific deployment, since the API otherwise rejects the request at apply time
+func validateTemplateDeploymentOnErrorDeployment(input []interface{}) error {
+	if len(input) == 0 || input[0] == nil {
+		return nil
+	}
+
+	v := input[0].(map[string]interface{})
+	onErrorType := v["type"].(string)
+	deploymentName := v["deployment_name"].(string)
+
+	if onErrorType == string(resources.OnErrorDeploymentTypeSpecificDeployment) && deploymentName == "" {
+		return fmt.Errorf("`on_error_deployment.0.deployment_name` must be specified when `on_error_deployment.0.type` is `%s`", resources.OnErrorDeploymentTypeSpecificDeployment)
+	}
+
+	if onErrorType == string(resources.OnErrorDeploymentTypeLastSuccessful) && deploymentName != "" {
+		return fmt.Errorf("`on_error_deployment.0.deployment_name` cannot be specified when `on_error_deployment.0.type` is `%s`", resources.OnErrorDeploymentTypeLastSuccessful)
+	}
+
+	return nil
+}
+
+func templateDeploymentTemplateLinkSchema() *pluginsdk.Schema {
+	return &pluginsdk.Schema{
+		Type:     pluginsdk.TypeList,
+		Optional: true,
+		MaxItems: 1,
+		ExactlyOneOf: []string{
+			"template_content",
+			"template_link",
+			"template_spec_version_id",
+		},
+		Elem: templateDeploymentLinkResource(),
+	}
+}
+
+func templateDeploymentParametersLinkSchema() *pluginsdk.Schema {
+	return &pluginsdk.Schema{
+		Type:     pluginsdk.TypeList,
+		Optional: true,
+		MaxItems: 1,
+		ConflictsWith: []string{
+			"parameters_content",
+			"parameters_content_secure",
+		},
+		Elem: templateDeploymentLinkResource(),
+	}
+}
+
+// templateDeploymentParametersContentSecureSchema returns the schema for `parameters_content_secure`, which is merged into
+// `parameters_content` when deploying - only a hash of the value is stored in the state, so that secrets (such as
+// `securestring` parameters) can be supplied without persisting them
+func templateDeploymentParametersContentSecureSchema() *pluginsdk.Schema {
+	return &pluginsdk.Schema{
+		Type:      pluginsdk.TypeString,
+		Optional:  true,
+		Sensitive: true,
+		ConflictsWith: []string{
+			"parameters_link",
+		},
+		ValidateFunc: validation.StringIsJSON,
+		StateFunc:    templateDeploymentSecureParametersStateFunc,
+	}
+}
+
+func templateDeploymentSecureParametersStateFunc(v interface{}) string {
+	s, ok := v.(string)
+	if !ok || s == "" {
+		return ""
+	}
+
+	hash := sha1.Sum([]byte(utils.NormalizeJson(s)))
+	return hex.EncodeToString(hash[:])
+}
+
+// templateDeploymentSecureParameters returns `parameters_content_secure` from the configuration, since the plan and state
+// only contain a hash of the value
+func templateDeploymentSecureParameters(d *pluginsdk.ResourceData) string {
+	config := d.GetRawConfig()
+	if config.IsNull() || !config.IsKnown() {
+		return ""
+	}
+	if v := config.GetAttr("parameters_content_secure"); v.IsKnown() && !v.IsNull() {
+		return v.AsString()
+	}
+
+	return ""
+}
+
+// templateDeploymentSecureParameterNames returns the names of the parameters specified in `parameters_content_secure`,
+// which are omitted from `parameters_content` regardless of their type within the Template
+func templateDeploymentSecureParameterNames(secureParametersContent string) []string {
+	names := make([]string, 0)
+	if secureParametersContent == "" {
+		return names
+	}
+
+	// this is best-effort, invalid JSON is surfaced when the parameters are expanded
+	secureParameters, err := expandTemplateDeploymentBody(secureParametersContent)
+	if err != nil {
+		return names
+	}
+	for k := range pointer.From(secureParameters) {
+		names = append(names, k)
+	}
+
+	return names
+}
+
+// expandTemplateDeploymentParameters combines `parameters_content` and `parameters_content_secure` into the Parameters
+// for the Deployment, returning nil when neither is specified
+func expandTemplateDeploymentParameters(parametersContent string, secureParametersContent string) (*map[string]interface{}, error) {
+	if parametersContent == "" && secureParametersContent == "" {
+		return nil, nil
+	}
+
+	output := make(map[string]interface{})
+	if parametersContent != "" {
+		parameters, err := expandTemplateDeploymentBody(parametersContent)
+		if err != nil {
+			return nil, fmt.Errorf("expanding `parameters_content`: %+v", err)
+		}
+		for k, v := range pointer.From(parameters) {
+			output[k] = v
+		}
+	}
+
+	if secureParametersContent != "" {
+		secureParameters, err := expandTemplateDeploymentBody(secureParametersContent)
+		if err != nil {
+			return nil, fmt.Errorf("expanding `parameters_content_secure`: %+v", err)
+		}
+		for k, v := range pointer.From(secureParameters) {
+			if _, exists := findTemplateDeploymentParameter(output, k); exists {
+				return nil, fmt.Errorf("the parameter %q is specified in both `parameters_content` and `parameters_content_secure`", k)
+			}
+			output[k] = v
+		}
+	}
+
+	return &output, nil
+}
+
+func templateDeploymentLinkResource() *pluginsdk.Resource {
+	return &pluginsdk.Resource{
+		Schema: map[string]*pluginsdk.Schema{
+			"uri": {
+				Type:         pluginsdk.TypeString,
+				Required:     true,
+				ValidateFunc: validate.TemplateDeploymentLinkURI,
+			},
+
+			"content_version": {
+				Type:         pluginsdk.TypeString,
+				Optional:     true,
+				ValidateFunc: validation.StringIsNotEmpty,
+			},
+
+			"query_string": {
+				Type:         pluginsdk.TypeString,
+				Optional:     true,
+				Sensitive:    true,
+				ValidateFunc: validation.StringIsNotEmpty,
+			},
+		},
+	}
+}
+
+func expandTemplateDeploymentTemplateLink(input []interface{}) *resources.TemplateLink {
+	if len(input) == 0 || input[0] == nil {
+		return nil
+	}
+
+	v := input[0].(map[string]interface{})
+	output := &resources.TemplateLink{
+		URI: utils.String(expandTemplateDeploymentLinkURI(v["uri"].(string), v["query_string"].(string))),
+	}
+
+	if contentVersion := v["content_version"].(string); contentVersion != "" {
+		output.ContentVersion = utils.String(contentVersion)
+	}
+
+	return output
+}
+
+func expandTemplateDeploymentParametersLink(input []interface{}) *resources.ParametersLink {
+	if len(input) == 0 || input[0] == nil {
+		return nil
+	}
+
+	v := input[0].(map[string]interface{})
+	output := &resources.ParametersLink{
+		URI: utils.String(expandTemplateDeploymentLinkURI(v["uri"].(string), v["query_string"].(string))),
+	}
+
+	if contentVersion := v["content_version"].(string); contentVersion != "" {
+		output.ContentVersion = utils.String(contentVersion)
+	}
+
+	return output
+}
+
+// expandTemplateDeploymentLinkURI appends the `query_string` (typically a SAS Token) to the `uri`, since this API
+// version doesn't expose a separate field for it
+func expandTemplateDeploymentLinkURI(uri string, queryString string) string {
+	queryString = strings.TrimPrefix(queryString, "?")
+	if queryString == "" {
+		return uri
+	}
+
+	return fmt.Sprintf("%s?%s", uri, queryString)
+}
+
+// flattenTemplateDeploymentLink splits the query string back out of the linked URI - when the API doesn't return it
+// the (sensitive) `query_string` is retained from the existing state
+func flattenTemplateDeploymentLink(uri *string, contentVersion *string, existing []interface{}) []interface{} {
+	if uri == nil || *uri == "" {
+		return []interface{}{}
+	}
+
+	linkUri := *uri
+	queryString := ""
+	if idx := strings.Index(linkUri, "?"); idx != -1 {
+		queryString = linkUri[idx+1:]
+		linkUri = linkUri[:idx]
+	}
+
+	if queryString == "" && len(existing) > 0 && existing[0] != nil {
+		queryString = existing[0].(map[string]interface{})["query_string"].(string)
+	}
+
+	version := ""
+	if contentVersion != nil {
+		version = *contentVersion
+	}
+
+	return []interface{}{
+		map[string]interface{}{
+			"uri":             linkUri,
+			"content_version": version,
+			"query_string":    queryString,
+		},
+	}
+}
+
+// expandTemplateDeploymentContent populates the Template, Template Link, Parameters and Parameters Link of a new Template
+// Deployment from `template_content`, `template_link`, `template_spec_version_id`, `parameters_content` and
+// `parameters_link` - which are common to all scopes
+func expandTemplateDeploymentContent(d *pluginsdk.ResourceData, props *resources.DeploymentProperties) error {
+	if templateRaw, ok := d.GetOk("template_content"); ok {
+		template, err := expandTemplateDeploymentBody(templateRaw.(string))
+		if err != nil {
+			return fmt.Errorf("expanding `template_content`: %+v", err)
+		}
+		props.Template = template
+	}
+
+	if templateSpecVersionID, ok := d.GetOk("template_spec_version_id"); ok {
+		props.TemplateLink = &resources.TemplateLink{
+			ID: utils.String(templateSpecVersionID.(string)),
+		}
+	}
+
+	if templateLink := expandTemplateDeploymentTemplateLink(d.Get("template_link").([]interface{})); templateLink != nil {
+		props.TemplateLink = templateLink
+	}
+
+	parameters, err := expandTemplateDeploymentParameters(d.Get("parameters_content").(string), templateDeploymentSecureParameters(d))
+	if err != nil {
+		return err
+	}
+	if parameters != nil {
+		props.Parameters = parameters
+	}
+
+	props.ParametersLink = expandTemplateDeploymentParametersLink(d.Get("parameters_link").([]interface{}))
+
+	return nil
+}
+
+// expandTemplateDeploymentContentForUpdate populates the Template, Template Link and Parameters of an existing Template
+// Deployment - since the API doesn't support PATCH the existing Template is retrieved using `exportTemplate` when unchanged
+func expandTemplateDeploymentContentForUpdate(d *pluginsdk.ResourceData, props *resources.DeploymentProperties, exportTemplate func() (interface{}, error)) error {
+	if parametersLink := expandTemplateDeploymentParametersLink(d.Get("parameters_link").([]interface{})); parametersLink != nil {
+		props.ParametersLink = parametersLink
+	} else {
+		// the API doesn't return the values of `securestring`/`secureobject` parameters, so these are always resent
+		parameters, err := expandTemplateDeploymentParameters(d.Get("parameters_content").(string), templateDeploymentSecureParameters(d))
+		if err != nil {
+			return err
+		}
+		if parameters != nil {
+			props.Parameters = parameters
+		}
+	}
+
+	if d.HasChange("template_content") {
+		templateContents, err := expandTemplateDeploymentBody(d.Get("template_content").(string))
+		if err != nil {
+			return fmt.Errorf("expanding `template_content`: %+v", err)
+		}
+
+		props.Template = templateContents
+	} else {
+		// retrieve the existing content and reuse that
+		exportedTemplate, err := exportTemplate()
+		if err != nil {
+			return fmt.Errorf("retrieving existing Template Contents: %+v", err)
+		}
+
+		props.Template = exportedTemplate
+	}
+
+	if d.HasChange("template_spec_version_id") {
+		props.TemplateLink = &resources.TemplateLink{
+			ID: utils.String(d.Get("template_spec_version_id").(string)),
+		}
+
+		if d.Get("template_spec_version_id").(string) != "" {
+			props.Template = nil
+		}
+	}
+
+	// a linked Template is re-read from the `uri` on every deployment, so the exported Template isn't sent
+	if templateLink := expandTemplateDeploymentTemplateLink(d.Get("template_link").([]interface{})); templateLink != nil {
+		props.TemplateLink = templateLink
+		props.Template = nil
+	}
+
+	return nil
+}
+
+// flattenTemplateDeploymentContent sets `parameters_content`, `parameters_link`, `output_content`, `template_link`,
+// `template_spec_version_id`, `correlation_id`, `duration` and `template_content` from the Deployment Properties and
+// the exported Template
+func flattenTemplateDeploymentContent(d *pluginsdk.ResourceData, props *resources.DeploymentPropertiesExtended, template interface{}) error {
+	if props != nil {
+		secureParameterNames := templateDeploymentSecureParameterNames(templateDeploymentSecureParameters(d))
+		if len(secureParameterNames) == 0 && d.Get("parameters_content_secure").(string) != "" {
+			// the configuration isn't available during a refresh and the state only contains a hash of
+			// `parameters_content_secure` - since a parameter can't be specified in both, any parameter which
+			// isn't within `parameters_content` must have been specified in `parameters_content_secure`
+			secureParameterNames = templateDeploymentParameterNamesNotIn(props.Parameters, d.Get("parameters_content").(string))
+		}
+		filteredParams := filterOutTemplateDeploymentParameters(props.Parameters, secureParameterNames)
+		flattenedParams, err := flattenTemplateDeploymentBody(filteredParams)
+		if err != nil {
+			return fmt.Errorf("flattening `parameters_content`: %+v", err)
+		}
+		d.Set("parameters_content", flattenedParams)
+
+		flattenedOutputs, err := flattenTemplateDeploymentBody(props.Outputs)
+		if err != nil {
+			return fmt.Errorf("flattening `output_content`: %+v", err)
+		}
+		d.Set("output_content", flattenedOutputs)
+
+		templateLinkId := ""
+		var templateLinkUri, templateLinkContentVersion *string
+		if props.TemplateLink != nil {
+			if props.TemplateLink.ID != nil {
+				templateLinkId = *props.TemplateLink.ID
+			}
+			templateLinkUri = props.TemplateLink.URI
+			templateLinkContentVersion = props.TemplateLink.ContentVersion
+		}
+		d.Set("template_spec_version_id", templateLinkId)
+
+		if err := d.Set("template_link", flattenTemplateDeploymentLink(templateLinkUri, templateLinkContentVersion, d.Get("template_link").([]interface{}))); err != nil {
+			return fmt.Errorf("setting `template_link`: %+v", err)
+		}
+
+		var parametersLinkUri, parametersLinkContentVersion *string
+		if props.ParametersLink != nil {
+			parametersLinkUri = props.ParametersLink.URI
+			parametersLinkContentVersion = props.ParametersLink.ContentVersion
+		}
+		if err := d.Set("parameters_link", flattenTemplateDeploymentLink(parametersLinkUri, parametersLinkContentVersion, d.Get("parameters_link").([]interface{}))); err != nil {
+			return fmt.Errorf("setting `parameters_link`: %+v", err)
+		}
+
+		d.Set("correlation_id", pointer.From(props.CorrelationID))
+		d.Set("duration", pointer.From(props.Duration))
+	}
+
+	flattenedTemplate, err := flattenTemplateDeploymentBody(template)
+	if err != nil {
+		return fmt.Errorf("flattening `template_content`: %+v", err)
+	}
+	d.Set("template_content", flattenedTemplate)
+
+	return nil
+}
+
+// templateDeploymentParametersCustomizeDiff validates that `parameters_content` supplies all of the required parameters
+// defined within `template_content` (and no others) at plan time, rather than failing during the apply
+func templateDeploymentParametersCustomizeDiff(ctx context.Context, d *pluginsdk.ResourceDiff, _ interface{}) error {
+	if !d.NewValueKnown("template_content") || !d.NewValueKnown("parameters_content") || !d.NewValueKnown("template_spec_version_id") {
+		return nil
+	}
+
+	// the parameters for a Template Spec Version aren't available at plan time
+	if d.Get("template_spec_version_id").(string) != "" {
+		return nil
+	}
+
+	// nor is the content of a linked Template or Parameters file
+	if len(d.Get("template_link").([]interface{})) > 0 || len(d.Get("parameters_link").([]interface{})) > 0 {
+		return nil
+	}
+
+	templateContent := d.Get("template_content").(string)
+	if templateContent == "" {
+		return nil
+	}
+
+	// `parameters_content` is Computed, so the value is taken from the configuration rather than the (prior) state
+	parametersContent := ""
+	parametersContentConfigured := false
+	if v := d.GetRawConfig().GetAttr("parameters_content"); !v.IsKnown() {
+		return nil
+	} else if !v.IsNull() {
+		parametersContent = v.AsString()
+		parametersContentConfigured = true
+	}
+
+	// the plan only contains a hash of `parameters_content_secure`, so the value is taken from the configuration
+	secureParametersContent := ""
+	if v := d.GetRawConfig().GetAttr("parameters_content_secure"); !v.IsKnown() {
+		return nil
+	} else if !v.IsNull() {
+		secureParametersContent = v.AsString()
+	}
+
+	return validateTemplateDeploymentParameters(templateContent, parametersContent, secureParametersContent, parametersContentConfigured)
+}
+
+// validateTemplateDeploymentParameters checks the parameters against those defined in the template - the check for
+// parameters which aren't defined in the template is only performed when `checkUnknownParameters` is true
+func validateTemplateDeploymentParameters(templateContent string, parametersContent string, secureParametersContent string, checkUnknownParameters bool) error {
+	var template struct {
+		Parameters map[string]interface{} `json:"parameters"`
+	}
+	if err := json.Unmarshal([]byte(templateContent), &template); err != nil {
+		return fmt.Errorf("parsing `template_content`: %+v", err)
+	}
+
+	parameters := make(map[string]interface{})
+	if parametersContent != "" {
+		if err := json.Unmarshal([]byte(parametersContent), &parameters); err != nil {
+			return fmt.Errorf("parsing `parameters_content`: %+v", err)
+		}
+	}
+	if secureParametersContent != "" {
+		secureParameters := make(map[string]interface{})
+		if err := json.Unmarshal([]byte(secureParametersContent), &secureParameters); err != nil {
+			return fmt.Errorf("parsing `parameters_content_secure`: %+v", err)
+		}
+		for k, v := range secureParameters {
+			parameters[k] = v
+		}
+	}
+
+	missing := make([]string, 0)
+	for name, definition := range template.Parameters {
+		if v, ok := definition.(map[string]interface{}); ok {
+			if _, hasDefault := v["defaultValue"]; hasDefault {
+				continue
+			}
+		}
+
+		if _, ok := findTemplateDeploymentParameter(parameters, name); !ok {
+			missing = append(missing, name)
+		}
+	}
+
+	unknown := make([]string, 0)
+	if checkUnknownParameters {
+		for name := range parameters {
+			if _, ok := findTemplateDeploymentParameter(template.Parameters, name); !ok {
+				unknown = append(unknown, name)
+			}
+		}
+	}
+
+	sort.Strings(missing)
+	sort.Strings(unknown)
+
+	errors := make([]string, 0)
+	if len(missing) > 0 {
+		errors = append(errors, fmt.Sprintf("the required parameters %q defined in `template_content` were not specified in `parameters_content` or `parameters_content_secure`", missing))
+	}
+	if len(unknown) > 0 {
+		errors = append(errors, fmt.Sprintf("the parameters %q specified in `parameters_content` or `parameters_content_secure` are not defined in `template_content`", unknown))
+	}
+	if len(errors) > 0 {
+		return fmt.Errorf("%s", strings.Join(errors, " and "))
+	}
+
+	return nil
+}
+
+// findTemplateDeploymentParameter looks up a parameter by name, since ARM treats parameter names case-insensitively
+func findTemplateDeploymentParameter(input map[string]interface{}, name string) (interface{}, bool) {
+	if v, ok := input[name]; ok {
+		return v, true
+	}
+
+	for k, v := range input {
+		if strings.EqualFold(k, name) {
+			return v, true
+		}
+	}
+
+	return nil, false
+}
+
 func expandTemplateDeploymentBody(input string) (*map[string]interface{}, error) {
 	var output map[string]interface{}
 
@@ -89,7 +656,34 @@ func flattenTemplateDeploymentBody(input interface{}) (*string, error) {
 	return &output, nil
 }
 
-func filterOutTemplateDeploymentParameters(input interface{}) interface{} {
+// templateDeploymentParameterNamesNotIn returns the names of the parameters returned by the API which aren't specified
+// within `parametersContent`
+func templateDeploymentParameterNamesNotIn(input interface{}, parametersContent string) []string {
+	names := make([]string, 0)
+	items, ok := input.(map[string]interface{})
+	if !ok {
+		return names
+	}
+
+	existing := make(map[string]interface{})
+	if parametersContent != "" {
+		if parameters, err := expandTemplateDeploymentBody(parametersContent); err == nil {
+			existing = pointer.From(parameters)
+		}
+	}
+
+	for k := range items {
+		if _, exists := findTemplateDeploymentParameter(existing, k); !exists {
+			names = append(names, k)
+		}
+	}
+
+	return names
+}
+
+// filterOutTemplateDeploymentParameters removes the `type` from each parameter returned by the API - and omits both the
+// secure parameters and those specified in `parameters_content_secure` (named in `secureParameterNames`)
+func filterOutTemplateDeploymentParameters(input interface{}, secureParameterNames []string) interface{} {
 	if input == nil {
 		return nil
 	}
@@ -125,11 +719,24 @@ func filterOutTemplateDeploymentParameters(input interface{}) interface{} {
 			continue
 		}
 
+		// parameters specified in `parameters_content_secure` are returned with their value when the Template
+		// defines these as a (non-secure) `string`/`object` - so these are omitted to keep the value out of the state
+		if isTemplateDeploymentSecureParameterName(secureParameterNames, topLevelKey) {
+			continue
+		}
+
 		// give us the original
 		output[topLevelKey] = topLevelValue
 
 		// then filter it if necessary
 		if innerVals, ok := topLevelValue.(map[string]interface{}); ok {
+			// the values of secure parameters aren't returned by the API, so these are omitted rather than being
+			// flattened as an empty object - they're specified using `parameters_content_secure`
+			if isTemplateDeploymentSecureParameter(innerVals) {
+				delete(output, topLevelKey)
+				continue
+			}
+
 			outputVals := make(map[string]interface{})
 			for innerKey, innerValue := range innerVals {
 				if strings.EqualFold("type", innerKey) {
@@ -145,6 +752,37 @@ func filterOutTemplateDeploymentParameters(input interface{}) interface{} {
 	return output
 }
 
+// isTemplateDeploymentSecureParameterName returns whether `name` is one of `secureParameterNames` - the names of
+// parameters are case-insensitive
+func isTemplateDeploymentSecureParameterName(secureParameterNames []string, name string) bool {
+	for _, v := range secureParameterNames {
+		if strings.EqualFold(v, name) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// isTemplateDeploymentSecureParameter returns whether the parameter returned by the API is a `securestring` or
+// `secureobject` parameter without a value
+func isTemplateDeploymentSecureParameter(input map[string]interface{}) bool {
+	if _, hasValue := input["value"]; hasValue {
+		return false
+	}
+
+	for k, v := range input {
+		if !strings.EqualFold(k, "type") {
+			continue
+		}
+		if t, ok := v.(string); ok && (strings.EqualFold(t, "securestring") || strings.EqualFold(t, "secureobject")) {
+			return true
+		}
+	}
+
+	return false
+}
+
 func deleteNestedResource(ctx context.Context, resourcesClient *resources.Client, resourceProviderApiVersions *map[string]string, nestedResource resources.Reference) error {
 	parsedId, err := azure.ParseAzureResourceID(*nestedResource.ID)
 	if err != nil {
@@ -0,0 +1,380 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package resource
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-provider-azurerm/utils"
+)
+
+func TestValidateTemplateDeploymentParameters(t *testing.T) {
+	template := `{
+  "$schema": "https://schema.management.azure.com/schemas/2015-01-01/deploymentTemplate.json#",
+  "contentVersion": "1.0.0.0",
+  "parameters": {
+    "requiredParam": {
+      "type": "string"
+    },
+    "optionalParam": {
+      "type": "string",
+      "defaultValue": "hello"
+    }
+  },
+  "resources": []
+}`
+
+	testData := []struct {
+		name                 string
+		template             string
+		parameters           string
+		secureParameters     string
+		parametersConfigured bool
+		valid                bool
+	}{
+		{
+			name:                 "required parameter specified",
+			template:             template,
+			parameters:           `{"requiredParam": {"value": "world"}}`,
+			parametersConfigured: true,
+			valid:                true,
+		},
+		{
+			name:                 "required and optional parameters specified",
+			template:             template,
+			parameters:           `{"requiredParam": {"value": "world"}, "optionalParam": {"value": "there"}}`,
+			parametersConfigured: true,
+			valid:                true,
+		},
+		{
+			name:                 "required parameter specified with different casing",
+			template:             template,
+			parameters:           `{"RequiredParam": {"value": "world"}}`,
+			parametersConfigured: true,
+			valid:                true,
+		},
+		{
+			name:                 "required parameter missing",
+			template:             template,
+			parameters:           `{"optionalParam": {"value": "there"}}`,
+			parametersConfigured: true,
+			valid:                false,
+		},
+		{
+			name:       "no parameters specified",
+			template:   template,
+			parameters: "",
+			valid:      false,
+		},
+		{
+			name:                 "unknown parameter specified",
+			template:             template,
+			parameters:           `{"requiredParam": {"value": "world"}, "unknownParam": {"value": "nope"}}`,
+			parametersConfigured: true,
+			valid:                false,
+		},
+		{
+			name:                 "required parameter specified in the secure parameters",
+			template:             template,
+			parameters:           `{"optionalParam": {"value": "there"}}`,
+			secureParameters:     `{"requiredParam": {"value": "world"}}`,
+			parametersConfigured: true,
+			valid:                true,
+		},
+		{
+			name:                 "unknown parameter specified in the secure parameters",
+			template:             template,
+			parameters:           `{"requiredParam": {"value": "world"}}`,
+			secureParameters:     `{"unknownParam": {"value": "nope"}}`,
+			parametersConfigured: true,
+			valid:                false,
+		},
+		{
+			name:             "unknown parameter specified in the secure parameters when parameters aren't configured",
+			template:         template,
+			secureParameters: `{"requiredParam": {"value": "world"}, "unknownParam": {"value": "nope"}}`,
+			valid:            true,
+		},
+		{
+			name:             "required parameter missing when parameters aren't configured",
+			template:         template,
+			secureParameters: `{"optionalParam": {"value": "there"}}`,
+			valid:            false,
+		},
+		{
+			name:                 "template without parameters",
+			template:             `{"resources": []}`,
+			parameters:           "{}",
+			parametersConfigured: true,
+			valid:                true,
+		},
+		{
+			name:                 "invalid template json",
+			template:             `{"resources": [}`,
+			parameters:           "{}",
+			parametersConfigured: true,
+			valid:                false,
+		},
+	}
+
+	for _, v := range testData {
+		t.Logf("[DEBUG] Testing %q..", v.name)
+
+		err := validateTemplateDeploymentParameters(v.template, v.parameters, v.secureParameters, v.parametersConfigured)
+		if v.valid && err != nil {
+			t.Fatalf("expected %q to be valid but got: %+v", v.name, err)
+		}
+		if !v.valid && err == nil {
+			t.Fatalf("expected %q to be invalid but got no error", v.name)
+		}
+	}
+}
+
+func TestExpandTemplateDeploymentLinkURI(t *testing.T) {
+	testData := []struct {
+		uri         string
+		queryString string
+		expected    string
+	}{
+		{
+			uri:      "https://example.blob.core.windows.net/templates/azuredeploy.json",
+			expected: "https://example.blob.core.windows.net/templates/azuredeploy.json",
+		},
+		{
+			uri:         "https://example.blob.core.windows.net/templates/azuredeploy.json",
+			queryString: "sv=2021-06-08&sig=abc",
+			expected:    "https://example.blob.core.windows.net/templates/azuredeploy.json?sv=2021-06-08&sig=abc",
+		},
+		{
+			uri:         "https://example.blob.core.windows.net/templates/azuredeploy.json",
+			queryString: "?sv=2021-06-08&sig=abc",
+			expected:    "https://example.blob.core.windows.net/templates/azuredeploy.json?sv=2021-06-08&sig=abc",
+		},
+	}
+
+	for _, v := range testData {
+		t.Logf("[DEBUG] Testing %q / %q..", v.uri, v.queryString)
+
+		actual := expandTemplateDeploymentLinkURI(v.uri, v.queryString)
+		if actual != v.expected {
+			t.Fatalf("expected %q but got %q", v.expected, actual)
+		}
+	}
+}
+
+func TestFlattenTemplateDeploymentLink(t *testing.T) {
+	existing := []interface{}{
+		map[string]interface{}{
+			"uri":             "https://example.blob.core.windows.net/templates/azuredeploy.json",
+			"content_version": "",
+			"query_string":    "sv=2021-06-08&sig=existing",
+		},
+	}
+
+	testData := []struct {
+		name           string
+		uri            *string
+		contentVersion *string
+		existing       []interface{}
+		expected       []interface{}
+	}{
+		{
+			name:     "no link",
+			expected: []interface{}{},
+		},
+		{
+			name:           "link without query string",
+			uri:            utils.String("https://example.blob.core.windows.net/templates/azuredeploy.json"),
+			contentVersion: utils.String("1.0.0.0"),
+			expected: []interface{}{
+				map[string]interface{}{
+					"uri":             "https://example.blob.core.windows.net/templates/azuredeploy.json",
+					"content_version": "1.0.0.0",
+					"query_string":    "",
+				},
+			},
+		},
+		{
+			name: "link with query string",
+			uri:  utils.String("https://example.blob.core.windows.net/templates/azuredeploy.json?sv=2021-06-08&sig=abc"),
+			expected: []interface{}{
+				map[string]interface{}{
+					"uri":             "https://example.blob.core.windows.net/templates/azuredeploy.json",
+					"content_version": "",
+					"query_string":    "sv=2021-06-08&sig=abc",
+				},
+			},
+		},
+		{
+			name:     "query string omitted by the API is retained from state",
+			uri:      utils.String("https://example.blob.core.windows.net/templates/azuredeploy.json"),
+			existing: existing,
+			expected: existing,
+		},
+	}
+
+	for _, v := range testData {
+		t.Logf("[DEBUG] Testing %q..", v.name)
+
+		actual := flattenTemplateDeploymentLink(v.uri, v.contentVersion, v.existing)
+		if !reflect.DeepEqual(actual, v.expected) {
+			t.Fatalf("expected %+v but got %+v", v.expected, actual)
+		}
+	}
+}
+
+func TestExpandTemplateDeploymentParameters(t *testing.T) {
+	testData := []struct {
+		name             string
+		parameters       string
+		secureParameters string
+		expected         *map[string]interface{}
+		error            bool
+	}{
+		{
+			name: "neither specified",
+		},
+		{
+			name:       "parameters only",
+			parameters: `{"first": {"value": "one"}}`,
+			expected: &map[string]interface{}{
+				"first": map[string]interface{}{"value": "one"},
+			},
+		},
+		{
+			name:             "secure parameters only",
+			secureParameters: `{"password": {"value": "secret"}}`,
+			expected: &map[string]interface{}{
+				"password": map[string]interface{}{"value": "secret"},
+			},
+		},
+		{
+			name:             "parameters and secure parameters are merged",
+			parameters:       `{"first": {"value": "one"}}`,
+			secureParameters: `{"password": {"value": "secret"}}`,
+			expected: &map[string]interface{}{
+				"first":    map[string]interface{}{"value": "one"},
+				"password": map[string]interface{}{"value": "secret"},
+			},
+		},
+		{
+			name:             "parameter specified in both",
+			parameters:       `{"password": {"value": "one"}}`,
+			secureParameters: `{"Password": {"value": "secret"}}`,
+			error:            true,
+		},
+		{
+			name:             "invalid secure parameters json",
+			secureParameters: `{"password": }`,
+			error:            true,
+		},
+	}
+
+	for _, v := range testData {
+		t.Logf("[DEBUG] Testing %q..", v.name)
+
+		actual, err := expandTemplateDeploymentParameters(v.parameters, v.secureParameters)
+		if err != nil {
+			if v.error {
+				continue
+			}
+
+			t.Fatalf("unexpected error: %+v", err)
+		}
+		if v.error {
+			t.Fatalf("expected an error but didn't get one")
+		}
+
+		if !reflect.DeepEqual(actual, v.expected) {
+			t.Fatalf("expected %+v but got %+v", v.expected, actual)
+		}
+	}
+}
+
+func TestFilterOutTemplateDeploymentParametersSecure(t *testing.T) {
+	input := map[string]interface{}{
+		"location": map[string]interface{}{
+			"type":  "String",
+			"value": "westeurope",
+		},
+		"password": map[string]interface{}{
+			"type": "SecureString",
+		},
+		"settings": map[string]interface{}{
+			"type": "SecureObject",
+		},
+	}
+	expected := map[string]interface{}{
+		"location": map[string]interface{}{
+			"value": "westeurope",
+		},
+	}
+
+	actual := filterOutTemplateDeploymentParameters(input, []string{})
+	if !reflect.DeepEqual(actual, expected) {
+		t.Fatalf("expected %+v but got %+v", expected, actual)
+	}
+}
+
+func TestFilterOutTemplateDeploymentParametersSpecifiedAsSecure(t *testing.T) {
+	// `adminPassword` is a `string` within the Template, so is returned with its value
+	input := map[string]interface{}{
+		"location": map[string]interface{}{
+			"type":  "String",
+			"value": "westeurope",
+		},
+		"adminPassword": map[string]interface{}{
+			"type":  "String",
+			"value": "ohhai",
+		},
+	}
+	expected := map[string]interface{}{
+		"location": map[string]interface{}{
+			"value": "westeurope",
+		},
+	}
+
+	secureParameterNames := templateDeploymentSecureParameterNames(`{"AdminPassword": {"value": "ohhai"}}`)
+	actual := filterOutTemplateDeploymentParameters(input, secureParameterNames)
+	if !reflect.DeepEqual(actual, expected) {
+		t.Fatalf("expected %+v but got %+v", expected, actual)
+	}
+}
+
+func TestTemplateDeploymentParameterNamesNotIn(t *testing.T) {
+	input := map[string]interface{}{
+		"location": map[string]interface{}{
+			"type":  "String",
+			"value": "westeurope",
+		},
+		"adminPassword": map[string]interface{}{
+			"type":  "String",
+			"value": "ohhai",
+		},
+	}
+
+	actual := templateDeploymentParameterNamesNotIn(input, `{"Location": {"value": "westeurope"}}`)
+	if !reflect.DeepEqual(actual, []string{"adminPassword"}) {
+		t.Fatalf("expected only `adminPassword` but got %+v", actual)
+	}
+}
+
+func TestTemplateDeploymentSecureParametersStateFunc(t *testing.T) {
+	if actual := templateDeploymentSecureParametersStateFunc(""); actual != "" {
+		t.Fatalf("expected an empty value to be stored as an empty string but got %q", actual)
+	}
+
+	value := `{"password": {"value": "secret"}}`
+	hashed := templateDeploymentSecureParametersStateFunc(value)
+	if hashed == "" || strings.Contains(hashed, "secret") {
+		t.Fatalf("expected the value to be hashed but got %q", hashed)
+	}
+
+	// formatting differences shouldn't show as a change
+	if actual := templateDeploymentSecureParametersStateFunc(`{ "password":{ "value":"secret" } }`); actual != hashed {
+		t.Fatalf("expected %q but got %q", hashed, actual)
+	}
+}
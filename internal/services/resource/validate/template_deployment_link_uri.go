@@ -0,0 +1,34 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package validate
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+)
+
+// TemplateDeploymentLinkURI validates the `uri` of a `template_link` or `parameters_link` block, which must be an
+// absolute HTTPS URI - any SAS Token or other query string must instead be specified in `query_string`
+func TemplateDeploymentLinkURI(i interface{}, k string) ([]string, []error) {
+	v, ok := i.(string)
+	if !ok {
+		return nil, []error{fmt.Errorf("expected type of %q to be string", k)}
+	}
+
+	u, err := url.Parse(v)
+	if err != nil || u.Host == "" {
+		return nil, []error{fmt.Errorf("%q must be an absolute URI, got %q", k, v)}
+	}
+
+	if !strings.EqualFold(u.Scheme, "https") {
+		return nil, []error{fmt.Errorf("%q must use the `https` scheme, got %q", k, v)}
+	}
+
+	if u.RawQuery != "" || strings.HasSuffix(v, "?") {
+		return nil, []error{fmt.Errorf("%q cannot contain a query string - use `query_string` to specify a SAS Token instead", k)}
+	}
+
+	return nil, nil
+}
@@ -0,0 +1,32 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package validate
+
+import "testing"
+
+func TestTemplateDeploymentLinkURI(t *testing.T) {
+	testCases := []struct {
+		input string
+		valid bool
+	}{
+		{input: "", valid: false},
+		{input: "hello", valid: false},
+		{input: "/templates/azuredeploy.json", valid: false},
+		{input: "http://example.blob.core.windows.net/templates/azuredeploy.json", valid: false},
+		{input: "https://example.blob.core.windows.net/templates/azuredeploy.json", valid: true},
+		{input: "HTTPS://example.blob.core.windows.net/templates/azuredeploy.json", valid: true},
+		{input: "https://raw.githubusercontent.com/Azure/azure-quickstart-templates/master/azuredeploy.json", valid: true},
+		{input: "https://example.blob.core.windows.net/templates/azuredeploy.json?", valid: false},
+		{input: "https://example.blob.core.windows.net/templates/azuredeploy.json?sv=2021-06-08&sig=abc", valid: false},
+	}
+
+	for _, testCase := range testCases {
+		t.Logf("Testing %q..", testCase.input)
+		warnings, errors := TemplateDeploymentLinkURI(testCase.input, "test")
+		valid := len(warnings) == 0 && len(errors) == 0
+		if valid != testCase.valid {
+			t.Fatalf("Expected %t but got %t - %d warnings %d errors", testCase.valid, valid, len(warnings), len(errors))
+		}
+	}
+}
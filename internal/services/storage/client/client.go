@@ -36,6 +36,9 @@ type Client struct {
 
 func NewClient(o *common.ClientOptions) (*Client, error) {
 	storageSuffix, ok := o.Environment.Storage.DomainSuffix()
+	if o.StorageDomainSuffix != "" {
+		storageSuffix, ok = &o.StorageDomainSuffix, true
+	}
 	if !ok {
 		return nil, fmt.Errorf("determining domain suffix for storage in environment: %s", o.Environment.Name)
 	}
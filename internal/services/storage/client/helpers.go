@@ -34,6 +34,7 @@ const (
 
 type AccountDetails struct {
 	Kind             storageaccounts.Kind
+	Tier             storageaccounts.SkuTier
 	IsHnsEnabled     bool
 	StorageAccountId commonids.StorageAccountId
 
@@ -197,6 +198,10 @@ func populateAccountDetails(accountId commonids.StorageAccountId, account storag
 		return nil, fmt.Errorf("populating details for %s: `model.Properties.PrimaryEndpoints` was nil", accountId)
 	}
 
+	if account.Sku != nil {
+		out.Tier = pointer.From(account.Sku.Tier)
+	}
+
 	props := *account.Properties
 	out.IsHnsEnabled = pointer.From(props.IsHnsEnabled)
 
@@ -0,0 +1,53 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package custompollers
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/hashicorp/go-azure-helpers/resourcemanager/commonids"
+	"github.com/hashicorp/go-azure-sdk/resource-manager/storage/2023-01-01/blobservice"
+	"github.com/hashicorp/go-azure-sdk/sdk/client"
+	"github.com/hashicorp/go-azure-sdk/sdk/client/pollers"
+)
+
+var _ pollers.PollerType = &storageAccountBlobRestorePolicyDisabledPoller{}
+
+type storageAccountBlobRestorePolicyDisabledPoller struct {
+	client *blobservice.BlobServiceClient
+	id     commonids.StorageAccountId
+}
+
+// When versioning remains enabled, `GetServiceProperties` can briefly continue to return the Restore Policy as enabled
+// once it's been disabled, so a custom poller is required to wait for the change to be reflected by the API
+func NewStorageAccountBlobRestorePolicyDisabledPoller(client *blobservice.BlobServiceClient, id commonids.StorageAccountId) *storageAccountBlobRestorePolicyDisabledPoller {
+	return &storageAccountBlobRestorePolicyDisabledPoller{
+		client: client,
+		id:     id,
+	}
+}
+
+func (p storageAccountBlobRestorePolicyDisabledPoller) Poll(ctx context.Context) (*pollers.PollResult, error) {
+	resp, err := p.client.GetServiceProperties(ctx, p.id)
+	if err != nil {
+		return nil, fmt.Errorf("retrieving Blob Service Properties for %s: %+v", p.id, err)
+	}
+
+	status := pollers.PollingStatusSucceeded
+	if model := resp.Model; model != nil && model.Properties != nil {
+		if restorePolicy := model.Properties.RestorePolicy; restorePolicy != nil && restorePolicy.Enabled {
+			status = pollers.PollingStatusInProgress
+		}
+	}
+
+	return &pollers.PollResult{
+		HttpResponse: &client.Response{
+			Response: resp.HttpResponse,
+		},
+		PollInterval: 5 * time.Second,
+		Status:       status,
+	}, nil
+}
@@ -0,0 +1,71 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package custompollers
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/hashicorp/go-azure-helpers/lang/pointer"
+	"github.com/hashicorp/go-azure-helpers/resourcemanager/commonids"
+	"github.com/hashicorp/go-azure-sdk/resource-manager/storage/2023-01-01/storageaccounts"
+	"github.com/hashicorp/go-azure-sdk/sdk/client"
+	"github.com/hashicorp/go-azure-sdk/sdk/client/pollers"
+)
+
+var _ pollers.PollerType = &storageAccountProvisioningStatePoller{}
+
+type storageAccountProvisioningStatePoller struct {
+	client *storageaccounts.StorageAccountsClient
+	id     commonids.StorageAccountId
+}
+
+// `CreateThenPoll` can complete whilst `GetProperties` still returns a Storage Account which isn't fully provisioned,
+// so a custom poller is required to wait for the `provisioningState` to become `Succeeded`
+func NewStorageAccountProvisioningStatePoller(client *storageaccounts.StorageAccountsClient, id commonids.StorageAccountId) *storageAccountProvisioningStatePoller {
+	return &storageAccountProvisioningStatePoller{
+		client: client,
+		id:     id,
+	}
+}
+
+func (p storageAccountProvisioningStatePoller) Poll(ctx context.Context) (*pollers.PollResult, error) {
+	resp, err := p.client.GetProperties(ctx, p.id, storageaccounts.DefaultGetPropertiesOperationOptions())
+	if err != nil {
+		return nil, fmt.Errorf("retrieving %s: %+v", p.id, err)
+	}
+
+	provisioningState := ""
+	if model := resp.Model; model != nil && model.Properties != nil {
+		provisioningState = string(pointer.From(model.Properties.ProvisioningState))
+	}
+
+	switch storageaccounts.ProvisioningState(provisioningState) {
+	case storageaccounts.ProvisioningStateSucceeded:
+		return &pollers.PollResult{
+			HttpResponse: &client.Response{
+				Response: resp.HttpResponse,
+			},
+			PollInterval: 5 * time.Second,
+			Status:       pollers.PollingStatusSucceeded,
+		}, nil
+
+	case storageaccounts.ProvisioningStateCreating, storageaccounts.ProvisioningStateResolvingDNS:
+		return &pollers.PollResult{
+			HttpResponse: &client.Response{
+				Response: resp.HttpResponse,
+			},
+			PollInterval: 5 * time.Second,
+			Status:       pollers.PollingStatusInProgress,
+		}, nil
+	}
+
+	return nil, pollers.PollingFailedError{
+		HttpResponse: &client.Response{
+			Response: resp.HttpResponse,
+		},
+		Message: fmt.Sprintf("unexpected provisioningState %q", provisioningState),
+	}
+}
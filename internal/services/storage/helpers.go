@@ -4,16 +4,378 @@
 package storage
 
 import (
+	"context"
+	"fmt"
+	"log"
+	"net/http"
 	"sort"
+	"strings"
+	"time"
 
+	"github.com/hashicorp/go-azure-helpers/lang/pointer"
+	"github.com/hashicorp/go-azure-helpers/lang/response"
+	"github.com/hashicorp/go-azure-helpers/resourcemanager/commonids"
+	"github.com/hashicorp/go-azure-helpers/resourcemanager/identity"
+	"github.com/hashicorp/go-azure-sdk/resource-manager/storage/2023-01-01/blobcontainers"
+	"github.com/hashicorp/go-azure-sdk/resource-manager/storage/2023-01-01/blobservice"
+	"github.com/hashicorp/go-azure-sdk/resource-manager/storage/2023-01-01/deletedaccounts"
+	"github.com/hashicorp/go-azure-sdk/resource-manager/storage/2023-01-01/managementpolicies"
 	"github.com/hashicorp/go-azure-sdk/resource-manager/storage/2023-01-01/storageaccounts"
+	"github.com/hashicorp/go-azure-sdk/sdk/client/pollers"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/services/storage/custompollers"
 )
 
-func sortedKeysFromSlice(input map[storageaccounts.Kind]struct{}) []string {
-	keys := make([]string, 0)
-	for key := range input {
-		keys = append(keys, string(key))
+// storageAccountListKeysOptions only requests the Kerberos keys when the Storage Account uses AADKERB for Azure Files
+// authentication, since requesting them for other accounts can be refused with a 403 in some tenants.
+func storageAccountListKeysOptions(model *storageaccounts.StorageAccount) storageaccounts.ListKeysOperationOptions {
+	opts := storageaccounts.DefaultListKeysOperationOptions()
+	if model == nil || model.Properties == nil || model.Properties.AzureFilesIdentityBasedAuthentication == nil {
+		return opts
 	}
-	sort.Strings(keys)
-	return keys
+
+	if model.Properties.AzureFilesIdentityBasedAuthentication.DirectoryServiceOptions == storageaccounts.DirectoryServiceOptionsAADKERB {
+		opts.Expand = pointer.To(storageaccounts.ListKeyExpandKerb)
+	}
+
+	return opts
+}
+
+// WaitForStorageAccountProvisioning waits for the `provisioningState` of the Storage Account to become `Succeeded`, since
+// the Storage Account can still be provisioning once a create/update has been polled - this should be used by any
+// resource which needs the Storage Account to be ready before configuring it.
+func WaitForStorageAccountProvisioning(ctx context.Context, client *storageaccounts.StorageAccountsClient, id commonids.StorageAccountId) error {
+	pollerType := custompollers.NewStorageAccountProvisioningStatePoller(client, id)
+	return waitForStorageAccountProvisioningUsingPoller(ctx, pollerType, id, 5*time.Second)
+}
+
+func waitForStorageAccountProvisioningUsingPoller(ctx context.Context, pollerType pollers.PollerType, id commonids.StorageAccountId, initialDelay time.Duration) error {
+	startTime := time.Now()
+	poller := pollers.NewPoller(pollerType, initialDelay, pollers.DefaultNumberOfDroppedConnectionsToAllow)
+	if err := poller.PollUntilDone(ctx); err != nil {
+		return fmt.Errorf("waiting for %s to finish provisioning: %+v", id, err)
+	}
+	log.Printf("[DEBUG] waited %s for %s to finish provisioning", time.Since(startTime), id)
+
+	return nil
+}
+
+// waitForStorageAccountIdentityPrincipalId re-retrieves the Storage Account until the Principal ID of the System Assigned
+// Identity has been populated, since this can be empty immediately after the identity has been enabled - which causes any
+// Role Assignments referencing it to fail. This is bounded to (at most) half of the remaining timeout, so that the rest of
+// the create/update (or the Data Source read) can complete - after which the Storage Account is returned as-is.
+func waitForStorageAccountIdentityPrincipalId(ctx context.Context, client *storageaccounts.StorageAccountsClient, id commonids.StorageAccountId, model *storageaccounts.StorageAccount) (*storageaccounts.StorageAccount, error) {
+	if !accountIdentityPrincipalIdPending(model) {
+		return model, nil
+	}
+
+	timeout := 2 * time.Minute
+	if deadline, ok := ctx.Deadline(); ok && time.Until(deadline)/2 < timeout {
+		timeout = time.Until(deadline) / 2
+	}
+	waitCtx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	for accountIdentityPrincipalIdPending(model) {
+		log.Printf("[DEBUG] waiting for the Principal ID of the System Assigned Identity for %s to be populated", id)
+		select {
+		case <-waitCtx.Done():
+			log.Printf("[WARN] timed out waiting for the Principal ID of the System Assigned Identity for %s to be populated", id)
+			return model, nil
+		case <-time.After(5 * time.Second):
+		}
+
+		resp, err := client.GetProperties(waitCtx, id, storageaccounts.DefaultGetPropertiesOperationOptions())
+		if err != nil {
+			if waitCtx.Err() != nil && ctx.Err() == nil {
+				log.Printf("[WARN] timed out waiting for the Principal ID of the System Assigned Identity for %s to be populated", id)
+				return model, nil
+			}
+			return nil, fmt.Errorf("retrieving %s: %+v", id, err)
+		}
+		if resp.Model != nil {
+			model = resp.Model
+		}
+	}
+
+	return model, nil
+}
+
+// accountIdentityPrincipalIdPending returns whether a System Assigned Identity is enabled for the Storage Account but its
+// Principal ID hasn't yet been populated.
+func accountIdentityPrincipalIdPending(model *storageaccounts.StorageAccount) bool {
+	if model == nil || model.Identity == nil {
+		return false
+	}
+
+	systemAssigned := model.Identity.Type == identity.TypeSystemAssigned || model.Identity.Type == identity.TypeSystemAssignedUserAssigned
+	return systemAssigned && model.Identity.PrincipalId == ""
+}
+
+// waitForAccountBlobRestorePolicyDisabled waits for the Blob Service Properties to report the Restore Policy as disabled,
+// since the API can briefly continue to return it as enabled - which would otherwise show as a diff in the next plan.
+func waitForAccountBlobRestorePolicyDisabled(ctx context.Context, client *blobservice.BlobServiceClient, id commonids.StorageAccountId) error {
+	pollerType := custompollers.NewStorageAccountBlobRestorePolicyDisabledPoller(client, id)
+	poller := pollers.NewPoller(pollerType, 5*time.Second, pollers.DefaultNumberOfDroppedConnectionsToAllow)
+	if err := poller.PollUntilDone(ctx); err != nil {
+		return fmt.Errorf("waiting for the `restore_policy` to be disabled for %s: %+v", id, err)
+	}
+
+	return nil
+}
+
+// storageAccountCreateError returns the error for a failed create of the Storage Account - when the create timed out
+// this explains that the Storage Account may still be provisioning, since large Premium accounts and SKU conversions
+// can take longer than the default timeout and the context deadline error alone isn't actionable.
+func storageAccountCreateError(ctx context.Context, id commonids.StorageAccountId, startTime time.Time, err error) error {
+	if ctx.Err() == context.DeadlineExceeded {
+		return fmt.Errorf("timed out after %s creating %s - the Storage Account may still be provisioning server-side (in which case it will need to be imported into the state), consider increasing `timeouts.create`: %+v", time.Since(startTime).Round(time.Second), id, err)
+	}
+
+	return fmt.Errorf("creating %s: %+v", id, err)
+}
+
+// storageAccountNameConflictError returns the error for a create of the Storage Account which conflicted with an existing
+// Storage Account - when a Storage Account with this name has recently been deleted and is being retained by account-level
+// soft delete this explains why, since the name is otherwise unavailable without any apparent reason.
+func storageAccountNameConflictError(ctx context.Context, client *deletedaccounts.DeletedAccountsClient, id commonids.StorageAccountId, err error) error {
+	// Storage Account names are globally unique, so the deleted account may have been in another region
+	resp, listErr := client.ListComplete(ctx, commonids.NewSubscriptionID(id.SubscriptionId))
+	if listErr != nil {
+		log.Printf("[DEBUG] unable to determine whether %s has been soft deleted: %+v", id, listErr)
+		return fmt.Errorf("creating %s: %+v", id, err)
+	}
+
+	deleted := findDeletedStorageAccount(resp.Items, id.StorageAccountName)
+	if deleted == nil {
+		return fmt.Errorf("creating %s: %+v", id, err)
+	}
+
+	return fmt.Errorf("creating %s: %s: %+v", id, storageAccountSoftDeletedMessage(deleted.Properties), err)
+}
+
+// findDeletedStorageAccount returns the soft deleted Storage Account with the specified name, regardless of the region
+// it was deleted from - or nil if there isn't one.
+func findDeletedStorageAccount(input []deletedaccounts.DeletedAccount, name string) *deletedaccounts.DeletedAccount {
+	for _, item := range input {
+		if strings.EqualFold(pointer.From(item.Name), name) {
+			return &item
+		}
+	}
+
+	return nil
+}
+
+// storageAccountSoftDeletedMessage returns a description of the soft deleted Storage Account which is retaining the name.
+func storageAccountSoftDeletedMessage(input *deletedaccounts.DeletedAccountProperties) string {
+	message := "a Storage Account with this name was recently deleted and is being retained by account-level soft delete, so the name can't be reused until it's been recovered or the retention period has elapsed"
+	if input == nil {
+		return message
+	}
+
+	if deletionTime := pointer.From(input.DeletionTime); deletionTime != "" {
+		message += fmt.Sprintf(" (deleted at %s", deletionTime)
+		if resourceId := pointer.From(input.StorageAccountResourceId); resourceId != "" {
+			message += fmt.Sprintf(" from %s", resourceId)
+		}
+		message += ")"
+	}
+
+	return message
+}
+
+// logStorageAccountRequestId logs the `x-ms-request-id` returned for an operation against the Storage Account, on both
+// success and failure, since this is required by Azure Support to locate the operation.
+func logStorageAccountRequestId(operation string, id commonids.StorageAccountId, resp *http.Response) {
+	if resp == nil {
+		return
+	}
+
+	log.Printf("[DEBUG] %s %s returned status %d with request ID %q", operation, id, resp.StatusCode, resp.Header.Get("x-ms-request-id"))
+}
+
+// flattenAccountMinimumTlsVersion returns the Minimum TLS Version for the Storage Account. Accounts created using older
+// API versions don't return this, in which case the lowest TLS Version supported by the API is in effect - this is
+// determined from the possible values (which sort in version order, e.g. `TLS1_0` < `TLS1_2`) so that newer versions
+// being added to the API don't change the default.
+func flattenAccountMinimumTlsVersion(input *storageaccounts.MinimumTlsVersion) string {
+	if input != nil {
+		return string(*input)
+	}
+
+	versions := storageaccounts.PossibleValuesForMinimumTlsVersion()
+	sort.Strings(versions)
+	return versions[0]
+}
+
+// storageAccountLastAccessTimeTrackingEnabled returns whether last access time tracking is enabled on the Blob Service
+// of the specified Storage Account, which is required for any lifecycle management rules based on the last access time.
+func storageAccountLastAccessTimeTrackingEnabled(ctx context.Context, client *blobservice.BlobServiceClient, id commonids.StorageAccountId) (bool, error) {
+	resp, err := client.GetServiceProperties(ctx, id)
+	if err != nil {
+		return false, fmt.Errorf("retrieving Blob Service Properties for %s: %+v", id, err)
+	}
+
+	if model := resp.Model; model != nil && model.Properties != nil && model.Properties.LastAccessTimeTrackingPolicy != nil {
+		return model.Properties.LastAccessTimeTrackingPolicy.Enable, nil
+	}
+
+	return false, nil
+}
+
+// ValidateObjectReplicationPrerequisites ensures that the source and destination Storage Accounts of an Object
+// Replication Policy meet its prerequisites - versioning and the change feed must be enabled on the source account,
+// and versioning must be enabled on the destination account.
+func ValidateObjectReplicationPrerequisites(ctx context.Context, client *blobservice.BlobServiceClient, source, destination commonids.StorageAccountId) error {
+	sourceResp, err := client.GetServiceProperties(ctx, source)
+	if err != nil {
+		return fmt.Errorf("retrieving Blob Service Properties for the source %s: %+v", source, err)
+	}
+	destinationResp, err := client.GetServiceProperties(ctx, destination)
+	if err != nil {
+		return fmt.Errorf("retrieving Blob Service Properties for the destination %s: %+v", destination, err)
+	}
+
+	var sourceProps, destinationProps *blobservice.BlobServicePropertiesProperties
+	if model := sourceResp.Model; model != nil {
+		sourceProps = model.Properties
+	}
+	if model := destinationResp.Model; model != nil {
+		destinationProps = model.Properties
+	}
+
+	if issues := objectReplicationPrerequisiteIssues(sourceProps, destinationProps); len(issues) > 0 {
+		return fmt.Errorf("the prerequisites for Object Replication from %s to %s are not met: %s", source, destination, strings.Join(issues, ", "))
+	}
+
+	return nil
+}
+
+// objectReplicationPrerequisiteIssues returns a description of each prerequisite for Object Replication which isn't met
+// by the Blob Service Properties of the source and destination Storage Accounts.
+func objectReplicationPrerequisiteIssues(source, destination *blobservice.BlobServicePropertiesProperties) []string {
+	issues := make([]string, 0)
+
+	if source == nil || !pointer.From(source.IsVersioningEnabled) {
+		issues = append(issues, "`blob_properties.versioning_enabled` must be enabled on the source Storage Account")
+	}
+	if source == nil || source.ChangeFeed == nil || !pointer.From(source.ChangeFeed.Enabled) {
+		issues = append(issues, "`blob_properties.change_feed_enabled` must be enabled on the source Storage Account")
+	}
+	if destination == nil || !pointer.From(destination.IsVersioningEnabled) {
+		issues = append(issues, "`blob_properties.versioning_enabled` must be enabled on the destination Storage Account")
+	}
+
+	return issues
+}
+
+// managementPolicyRulesUseLastAccessTime returns whether any of the specified rules contain a base blob action
+// which is based on the last access time of the blob.
+func managementPolicyRulesUseLastAccessTime(rules []managementpolicies.ManagementPolicyRule) bool {
+	for _, rule := range rules {
+		baseBlob := rule.Definition.Actions.BaseBlob
+		if baseBlob == nil {
+			continue
+		}
+
+		for _, action := range []*managementpolicies.DateAfterModification{baseBlob.TierToCool, baseBlob.TierToArchive, baseBlob.TierToCold, baseBlob.Delete} {
+			if action != nil && action.DaysAfterLastAccessTimeGreaterThan != nil {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+// storageAccountPublicContainers returns the names of the containers within the specified Storage Account which have
+// a public access level (e.g. `blob` or `container`) configured, sorted alphabetically. When the containers can't be
+// listed (e.g. due to a lack of permissions) these are treated as unknown and an empty list is returned.
+func storageAccountPublicContainers(ctx context.Context, client *blobcontainers.BlobContainersClient, id commonids.StorageAccountId) ([]string, error) {
+	resp, err := client.ListComplete(ctx, id, blobcontainers.DefaultListOperationOptions())
+	if err != nil {
+		if response.WasForbidden(resp.LatestHttpResponse) || response.WasNotFound(resp.LatestHttpResponse) {
+			log.Printf("[DEBUG] unable to list the containers within %s, treating the public containers as unknown: %+v", id, err)
+			return make([]string, 0), nil
+		}
+		return nil, err
+	}
+
+	output := make([]string, 0)
+	for _, item := range resp.Items {
+		if item.Name == nil || item.Properties == nil {
+			continue
+		}
+
+		if publicAccess := pointer.From(item.Properties.PublicAccess); publicAccess != "" && publicAccess != blobcontainers.PublicAccessNone {
+			output = append(output, *item.Name)
+		}
+	}
+	sort.Strings(output)
+
+	return output, nil
+}
+
+// storageAccountReplicationTypeFromSkuName returns the replication type portion of a SKU name
+// (e.g. `LRS` for `Standard_LRS`), or an empty string if the SKU name is malformed. Since the API
+// doesn't consistently case the SKU name, this is normalized to the (uppercase) values in the schema.
+func storageAccountReplicationTypeFromSkuName(input storageaccounts.SkuName) string {
+	parts := strings.Split(string(input), "_")
+	if len(parts) < 2 {
+		return ""
+	}
+	return strings.ToUpper(parts[1])
+}
+
+// orderedAccountBlobServicePropertiesUpdates returns the Blob Service Properties payloads which need to be sent (in order)
+// to move from the existing to the desired configuration, since the API rejects some combinations of changes made at once:
+//
+// 1. the Restore Policy is disabled first, since it depends on versioning, the change feed and the delete retention policy
+// (see https://github.com/Azure/azure-rest-api-specs/issues/11237)
+// 2. versioning is enabled next, since the restore policy depends on it
+// 3. the remaining properties (including the retention policies) are then applied in full
+//
+// Disabling versioning is left to the final payload, so that the settings depending on it are turned off in the same request.
+func orderedAccountBlobServicePropertiesUpdates(existing, desired *blobservice.BlobServicePropertiesProperties) []blobservice.BlobServiceProperties {
+	payloads := make([]blobservice.BlobServiceProperties, 0)
+	if desired == nil {
+		return payloads
+	}
+
+	if existing != nil {
+		if accountBlobRestorePolicyDisabled(existing, desired) {
+			payloads = append(payloads, blobservice.BlobServiceProperties{
+				Properties: &blobservice.BlobServicePropertiesProperties{
+					RestorePolicy: &blobservice.RestorePolicyProperties{
+						Enabled: false,
+					},
+				},
+			})
+		}
+
+		if !pointer.From(existing.IsVersioningEnabled) && pointer.From(desired.IsVersioningEnabled) {
+			payloads = append(payloads, blobservice.BlobServiceProperties{
+				Properties: &blobservice.BlobServicePropertiesProperties{
+					IsVersioningEnabled: pointer.To(true),
+				},
+			})
+		}
+	}
+
+	payloads = append(payloads, blobservice.BlobServiceProperties{
+		Properties: desired,
+	})
+
+	return payloads
+}
+
+// accountBlobRestorePolicyDisabled returns whether the Restore Policy is enabled in the existing Blob Service Properties
+// but not in the desired Blob Service Properties.
+func accountBlobRestorePolicyDisabled(existing, desired *blobservice.BlobServicePropertiesProperties) bool {
+	if existing == nil || desired == nil {
+		return false
+	}
+
+	existingRestorePolicyEnabled := existing.RestorePolicy != nil && existing.RestorePolicy.Enabled
+	desiredRestorePolicyEnabled := desired.RestorePolicy != nil && desired.RestorePolicy.Enabled
+	return existingRestorePolicyEnabled && !desiredRestorePolicyEnabled
 }
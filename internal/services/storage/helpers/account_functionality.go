@@ -0,0 +1,51 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package helpers
+
+import (
+	"slices"
+
+	"github.com/hashicorp/go-azure-sdk/resource-manager/storage/2023-01-01/storageaccounts"
+)
+
+// StorageAccountServiceSupportLevel describes which Data Plane services are available for a Storage Account.
+type StorageAccountServiceSupportLevel struct {
+	SupportBlob          bool
+	SupportQueue         bool
+	SupportShare         bool
+	SupportStaticWebsite bool
+}
+
+// AvailableFunctionalityForAccount returns which Data Plane services are available for a Storage Account with the
+// specified kind, tier and replication type.
+func AvailableFunctionalityForAccount(kind storageaccounts.Kind, tier storageaccounts.SkuTier, replicationType string) StorageAccountServiceSupportLevel {
+	// FileStorage doesn't support blob
+	supportBlob := kind != storageaccounts.KindFileStorage
+
+	// Queue is only supported for Storage and StorageV2, in Standard sku tier.
+	supportQueue := tier == storageaccounts.SkuTierStandard && (kind == storageaccounts.KindStorageVTwo ||
+		(kind == storageaccounts.KindStorage &&
+			// Per local test, only LRS/GRS/RAGRS Storage V1 accounts support queue endpoint.
+			// GZRS and RAGZRS is invalid, while ZRS is valid but has no queue endpoint.
+			slices.Contains([]string{"LRS", "GRS", "RAGRS"}, replicationType)))
+
+	// File share is only supported for StorageV2 and FileStorage.
+	// See: https://docs.microsoft.com/en-us/azure/storage/files/storage-files-planning#management-concepts
+	// Per test, the StorageV2 with Premium sku tier also doesn't support file share.
+	supportShare := kind == storageaccounts.KindFileStorage || (tier != storageaccounts.SkuTierPremium && (kind == storageaccounts.KindStorageVTwo ||
+		(kind == storageaccounts.KindStorage &&
+			// Per local test, only LRS/GRS/RAGRS Storage V1 accounts support file endpoint.
+			// GZRS and RAGZRS is invalid, while ZRS is valid but has no file endpoint.
+			slices.Contains([]string{"LRS", "GRS", "RAGRS"}, replicationType))))
+
+	// Static Website is only supported for StorageV2 (not for Storage(v1)) and BlockBlobStorage
+	supportStaticWebSite := kind == storageaccounts.KindStorageVTwo || kind == storageaccounts.KindBlockBlobStorage
+
+	return StorageAccountServiceSupportLevel{
+		SupportBlob:          supportBlob,
+		SupportQueue:         supportQueue,
+		SupportShare:         supportShare,
+		SupportStaticWebsite: supportStaticWebSite,
+	}
+}
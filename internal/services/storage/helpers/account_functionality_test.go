@@ -0,0 +1,116 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package helpers
+
+import (
+	"testing"
+
+	"github.com/hashicorp/go-azure-sdk/resource-manager/storage/2023-01-01/storageaccounts"
+)
+
+func TestAvailableFunctionalityForAccount(t *testing.T) {
+	testData := []struct {
+		kind            storageaccounts.Kind
+		tier            storageaccounts.SkuTier
+		replicationType string
+		expected        StorageAccountServiceSupportLevel
+	}{
+		// Storage (v1)
+		{
+			kind:            storageaccounts.KindStorage,
+			tier:            storageaccounts.SkuTierStandard,
+			replicationType: "LRS",
+			expected:        StorageAccountServiceSupportLevel{SupportBlob: true, SupportQueue: true, SupportShare: true},
+		},
+		{
+			kind:            storageaccounts.KindStorage,
+			tier:            storageaccounts.SkuTierStandard,
+			replicationType: "GRS",
+			expected:        StorageAccountServiceSupportLevel{SupportBlob: true, SupportQueue: true, SupportShare: true},
+		},
+		{
+			kind:            storageaccounts.KindStorage,
+			tier:            storageaccounts.SkuTierStandard,
+			replicationType: "RAGRS",
+			expected:        StorageAccountServiceSupportLevel{SupportBlob: true, SupportQueue: true, SupportShare: true},
+		},
+		{
+			kind:            storageaccounts.KindStorage,
+			tier:            storageaccounts.SkuTierStandard,
+			replicationType: "ZRS",
+			expected:        StorageAccountServiceSupportLevel{SupportBlob: true},
+		},
+		{
+			kind:            storageaccounts.KindStorage,
+			tier:            storageaccounts.SkuTierPremium,
+			replicationType: "LRS",
+			expected:        StorageAccountServiceSupportLevel{SupportBlob: true},
+		},
+
+		// StorageV2
+		{
+			kind:            storageaccounts.KindStorageVTwo,
+			tier:            storageaccounts.SkuTierStandard,
+			replicationType: "LRS",
+			expected:        StorageAccountServiceSupportLevel{SupportBlob: true, SupportQueue: true, SupportShare: true, SupportStaticWebsite: true},
+		},
+		{
+			kind:            storageaccounts.KindStorageVTwo,
+			tier:            storageaccounts.SkuTierStandard,
+			replicationType: "RAGZRS",
+			expected:        StorageAccountServiceSupportLevel{SupportBlob: true, SupportQueue: true, SupportShare: true, SupportStaticWebsite: true},
+		},
+		{
+			kind:            storageaccounts.KindStorageVTwo,
+			tier:            storageaccounts.SkuTierPremium,
+			replicationType: "LRS",
+			expected:        StorageAccountServiceSupportLevel{SupportBlob: true, SupportStaticWebsite: true},
+		},
+
+		// BlobStorage
+		{
+			kind:            storageaccounts.KindBlobStorage,
+			tier:            storageaccounts.SkuTierStandard,
+			replicationType: "LRS",
+			expected:        StorageAccountServiceSupportLevel{SupportBlob: true},
+		},
+
+		// BlockBlobStorage
+		{
+			kind:            storageaccounts.KindBlockBlobStorage,
+			tier:            storageaccounts.SkuTierPremium,
+			replicationType: "LRS",
+			expected:        StorageAccountServiceSupportLevel{SupportBlob: true, SupportStaticWebsite: true},
+		},
+		{
+			kind:            storageaccounts.KindBlockBlobStorage,
+			tier:            storageaccounts.SkuTierPremium,
+			replicationType: "ZRS",
+			expected:        StorageAccountServiceSupportLevel{SupportBlob: true, SupportStaticWebsite: true},
+		},
+
+		// FileStorage
+		{
+			kind:            storageaccounts.KindFileStorage,
+			tier:            storageaccounts.SkuTierPremium,
+			replicationType: "LRS",
+			expected:        StorageAccountServiceSupportLevel{SupportShare: true},
+		},
+		{
+			kind:            storageaccounts.KindFileStorage,
+			tier:            storageaccounts.SkuTierPremium,
+			replicationType: "ZRS",
+			expected:        StorageAccountServiceSupportLevel{SupportShare: true},
+		},
+	}
+
+	for _, v := range testData {
+		t.Logf("[DEBUG] Testing %q / %q / %q", v.kind, v.tier, v.replicationType)
+
+		actual := AvailableFunctionalityForAccount(v.kind, v.tier, v.replicationType)
+		if actual != v.expected {
+			t.Fatalf("Expected %+v but got %+v", v.expected, actual)
+		}
+	}
+}
@@ -0,0 +1,634 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package storage
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/go-azure-helpers/lang/pointer"
+	"github.com/hashicorp/go-azure-helpers/resourcemanager/commonids"
+	"github.com/hashicorp/go-azure-helpers/resourcemanager/identity"
+	"github.com/hashicorp/go-azure-sdk/resource-manager/storage/2023-01-01/blobservice"
+	"github.com/hashicorp/go-azure-sdk/resource-manager/storage/2023-01-01/deletedaccounts"
+	"github.com/hashicorp/go-azure-sdk/resource-manager/storage/2023-01-01/storageaccounts"
+	"github.com/hashicorp/go-azure-sdk/sdk/client/pollers"
+)
+
+func TestStorageAccountReplicationTypeFromSkuName(t *testing.T) {
+	testData := []struct {
+		input    storageaccounts.SkuName
+		expected string
+	}{
+		{
+			input:    storageaccounts.SkuNameStandardLRS,
+			expected: "LRS",
+		},
+		{
+			input:    storageaccounts.SkuNameStandardRAGZRS,
+			expected: "RAGZRS",
+		},
+		{
+			input:    storageaccounts.SkuNamePremiumZRS,
+			expected: "ZRS",
+		},
+		{
+			input:    storageaccounts.SkuName("Standard_RaGrs"),
+			expected: "RAGRS",
+		},
+		{
+			input:    storageaccounts.SkuName("premium_lrs"),
+			expected: "LRS",
+		},
+		{
+			input:    storageaccounts.SkuName("Standard"),
+			expected: "",
+		},
+		{
+			input:    storageaccounts.SkuName(""),
+			expected: "",
+		},
+	}
+
+	for _, v := range testData {
+		t.Logf("[DEBUG] Testing %q", v.input)
+
+		actual := storageAccountReplicationTypeFromSkuName(v.input)
+		if actual != v.expected {
+			t.Fatalf("Expected %q but got %q", v.expected, actual)
+		}
+	}
+}
+
+func TestOrderedAccountBlobServicePropertiesUpdates(t *testing.T) {
+	restorePolicy := &blobservice.RestorePolicyProperties{
+		Enabled: true,
+		Days:    pointer.To(int64(5)),
+	}
+	deleteRetentionPolicy := &blobservice.DeleteRetentionPolicy{
+		Enabled:              pointer.To(true),
+		Days:                 pointer.To(int64(7)),
+		AllowPermanentDelete: pointer.To(true),
+	}
+	disableRestorePolicy := blobservice.BlobServiceProperties{
+		Properties: &blobservice.BlobServicePropertiesProperties{
+			RestorePolicy: &blobservice.RestorePolicyProperties{
+				Enabled: false,
+			},
+		},
+	}
+	enableVersioning := blobservice.BlobServiceProperties{
+		Properties: &blobservice.BlobServicePropertiesProperties{
+			IsVersioningEnabled: pointer.To(true),
+		},
+	}
+
+	testData := []struct {
+		name     string
+		existing *blobservice.BlobServicePropertiesProperties
+		desired  *blobservice.BlobServicePropertiesProperties
+		expected []blobservice.BlobServiceProperties
+	}{
+		{
+			name:     "no desired properties",
+			existing: &blobservice.BlobServicePropertiesProperties{},
+			desired:  nil,
+			expected: []blobservice.BlobServiceProperties{},
+		},
+		{
+			name:     "no existing properties",
+			existing: nil,
+			desired: &blobservice.BlobServicePropertiesProperties{
+				IsVersioningEnabled: pointer.To(true),
+			},
+			expected: []blobservice.BlobServiceProperties{
+				{
+					Properties: &blobservice.BlobServicePropertiesProperties{
+						IsVersioningEnabled: pointer.To(true),
+					},
+				},
+			},
+		},
+		{
+			name: "unrelated change",
+			existing: &blobservice.BlobServicePropertiesProperties{
+				IsVersioningEnabled: pointer.To(true),
+				RestorePolicy:       restorePolicy,
+			},
+			desired: &blobservice.BlobServicePropertiesProperties{
+				IsVersioningEnabled:   pointer.To(true),
+				RestorePolicy:         restorePolicy,
+				DefaultServiceVersion: pointer.To("2020-06-12"),
+			},
+			expected: []blobservice.BlobServiceProperties{
+				{
+					Properties: &blobservice.BlobServicePropertiesProperties{
+						IsVersioningEnabled:   pointer.To(true),
+						RestorePolicy:         restorePolicy,
+						DefaultServiceVersion: pointer.To("2020-06-12"),
+					},
+				},
+			},
+		},
+		{
+			name: "restore policy removed alongside a retention change",
+			existing: &blobservice.BlobServicePropertiesProperties{
+				IsVersioningEnabled: pointer.To(true),
+				RestorePolicy:       restorePolicy,
+			},
+			desired: &blobservice.BlobServicePropertiesProperties{
+				IsVersioningEnabled:   pointer.To(true),
+				RestorePolicy:         &blobservice.RestorePolicyProperties{Enabled: false},
+				DeleteRetentionPolicy: deleteRetentionPolicy,
+			},
+			expected: []blobservice.BlobServiceProperties{
+				disableRestorePolicy,
+				{
+					Properties: &blobservice.BlobServicePropertiesProperties{
+						IsVersioningEnabled:   pointer.To(true),
+						RestorePolicy:         &blobservice.RestorePolicyProperties{Enabled: false},
+						DeleteRetentionPolicy: deleteRetentionPolicy,
+					},
+				},
+			},
+		},
+		{
+			name: "versioning enabled alongside a restore policy",
+			existing: &blobservice.BlobServicePropertiesProperties{
+				IsVersioningEnabled: pointer.To(false),
+			},
+			desired: &blobservice.BlobServicePropertiesProperties{
+				IsVersioningEnabled: pointer.To(true),
+				RestorePolicy:       restorePolicy,
+			},
+			expected: []blobservice.BlobServiceProperties{
+				enableVersioning,
+				{
+					Properties: &blobservice.BlobServicePropertiesProperties{
+						IsVersioningEnabled: pointer.To(true),
+						RestorePolicy:       restorePolicy,
+					},
+				},
+			},
+		},
+		{
+			name: "restore policy and versioning disabled",
+			existing: &blobservice.BlobServicePropertiesProperties{
+				IsVersioningEnabled: pointer.To(true),
+				RestorePolicy:       restorePolicy,
+			},
+			desired: &blobservice.BlobServicePropertiesProperties{
+				IsVersioningEnabled: pointer.To(false),
+			},
+			expected: []blobservice.BlobServiceProperties{
+				disableRestorePolicy,
+				{
+					Properties: &blobservice.BlobServicePropertiesProperties{
+						IsVersioningEnabled: pointer.To(false),
+					},
+				},
+			},
+		},
+	}
+
+	for _, v := range testData {
+		t.Logf("[DEBUG] Testing %q", v.name)
+
+		actual := orderedAccountBlobServicePropertiesUpdates(v.existing, v.desired)
+		if !reflect.DeepEqual(actual, v.expected) {
+			t.Fatalf("Expected %+v but got %+v", v.expected, actual)
+		}
+	}
+}
+
+func TestStorageAccountListKeysOptions(t *testing.T) {
+	testData := []struct {
+		name     string
+		input    *storageaccounts.StorageAccount
+		expected *storageaccounts.ListKeyExpand
+	}{
+		{
+			name: "no model",
+		},
+		{
+			name:  "no azure files authentication",
+			input: &storageaccounts.StorageAccount{Properties: &storageaccounts.StorageAccountProperties{}},
+		},
+		{
+			name: "active directory",
+			input: &storageaccounts.StorageAccount{
+				Properties: &storageaccounts.StorageAccountProperties{
+					AzureFilesIdentityBasedAuthentication: &storageaccounts.AzureFilesIdentityBasedAuthentication{
+						DirectoryServiceOptions: storageaccounts.DirectoryServiceOptionsAD,
+					},
+				},
+			},
+		},
+		{
+			name: "aad kerberos",
+			input: &storageaccounts.StorageAccount{
+				Properties: &storageaccounts.StorageAccountProperties{
+					AzureFilesIdentityBasedAuthentication: &storageaccounts.AzureFilesIdentityBasedAuthentication{
+						DirectoryServiceOptions: storageaccounts.DirectoryServiceOptionsAADKERB,
+					},
+				},
+			},
+			expected: pointer.To(storageaccounts.ListKeyExpandKerb),
+		},
+	}
+
+	for _, v := range testData {
+		t.Logf("[DEBUG] Testing %q..", v.name)
+
+		actual := storageAccountListKeysOptions(v.input)
+		if !reflect.DeepEqual(actual.Expand, v.expected) {
+			t.Fatalf("expected %+v but got %+v", v.expected, actual.Expand)
+		}
+	}
+}
+
+func TestFlattenAccountMinimumTlsVersion(t *testing.T) {
+	t.Logf("[DEBUG] Testing a configured value..")
+	if actual := flattenAccountMinimumTlsVersion(pointer.To(storageaccounts.MinimumTlsVersionTLSOneTwo)); actual != string(storageaccounts.MinimumTlsVersionTLSOneTwo) {
+		t.Fatalf("expected %q but got %q", string(storageaccounts.MinimumTlsVersionTLSOneTwo), actual)
+	}
+
+	t.Logf("[DEBUG] Testing a nil value..")
+	actual := flattenAccountMinimumTlsVersion(nil)
+	if actual != string(storageaccounts.MinimumTlsVersionTLSOneZero) {
+		t.Fatalf("expected %q but got %q", string(storageaccounts.MinimumTlsVersionTLSOneZero), actual)
+	}
+	for _, v := range storageaccounts.PossibleValuesForMinimumTlsVersion() {
+		if v < actual {
+			t.Fatalf("expected %q to be the lowest possible value but %q is lower", actual, v)
+		}
+	}
+}
+
+func TestObjectReplicationPrerequisiteIssues(t *testing.T) {
+	enabled := &blobservice.BlobServicePropertiesProperties{
+		IsVersioningEnabled: pointer.To(true),
+		ChangeFeed: &blobservice.ChangeFeed{
+			Enabled: pointer.To(true),
+		},
+	}
+	versioningOnly := &blobservice.BlobServicePropertiesProperties{
+		IsVersioningEnabled: pointer.To(true),
+	}
+
+	testData := []struct {
+		name           string
+		source         *blobservice.BlobServicePropertiesProperties
+		destination    *blobservice.BlobServicePropertiesProperties
+		expectedIssues int
+	}{
+		{
+			name:           "prerequisites met",
+			source:         enabled,
+			destination:    versioningOnly,
+			expectedIssues: 0,
+		},
+		{
+			name:           "source change feed disabled",
+			source:         versioningOnly,
+			destination:    versioningOnly,
+			expectedIssues: 1,
+		},
+		{
+			name:           "destination versioning disabled",
+			source:         enabled,
+			destination:    &blobservice.BlobServicePropertiesProperties{},
+			expectedIssues: 1,
+		},
+		{
+			name:           "no properties returned",
+			source:         nil,
+			destination:    nil,
+			expectedIssues: 3,
+		},
+	}
+
+	for _, v := range testData {
+		t.Logf("[DEBUG] Testing %q..", v.name)
+
+		if actual := objectReplicationPrerequisiteIssues(v.source, v.destination); len(actual) != v.expectedIssues {
+			t.Fatalf("expected %d issues but got %d: %+v", v.expectedIssues, len(actual), actual)
+		}
+	}
+}
+
+func TestStorageAccountCreateError(t *testing.T) {
+	id := commonids.NewStorageAccountID("12345678-1234-9876-4563-123456789012", "resGroup1", "account1")
+	startTime := time.Now().Add(-90 * time.Minute)
+
+	t.Logf("[DEBUG] Testing a failed create..")
+	if err := storageAccountCreateError(context.Background(), id, startTime, fmt.Errorf("bad request")); strings.Contains(err.Error(), "timeouts.create") {
+		t.Fatalf("expected the error not to suggest increasing the timeout but got: %+v", err)
+	}
+
+	t.Logf("[DEBUG] Testing a timed out create..")
+	ctx, cancel := context.WithDeadline(context.Background(), time.Now().Add(-time.Second))
+	defer cancel()
+	err := storageAccountCreateError(ctx, id, startTime, ctx.Err())
+	if !strings.Contains(err.Error(), "timeouts.create") || !strings.Contains(err.Error(), "1h30m0s") {
+		t.Fatalf("expected the error to include the elapsed time and suggest increasing the timeout but got: %+v", err)
+	}
+}
+
+func TestStorageAccountSoftDeletedMessage(t *testing.T) {
+	testData := []struct {
+		name     string
+		input    *deletedaccounts.DeletedAccountProperties
+		contains string
+	}{
+		{
+			name:     "no properties",
+			input:    nil,
+			contains: "account-level soft delete",
+		},
+		{
+			name: "deletion time",
+			input: &deletedaccounts.DeletedAccountProperties{
+				DeletionTime: pointer.To("2024-01-02T03:04:05Z"),
+			},
+			contains: "(deleted at 2024-01-02T03:04:05Z)",
+		},
+		{
+			name: "deletion time and resource id",
+			input: &deletedaccounts.DeletedAccountProperties{
+				DeletionTime:             pointer.To("2024-01-02T03:04:05Z"),
+				StorageAccountResourceId: pointer.To("/subscriptions/12345678-1234-9876-4563-123456789012/resourceGroups/resGroup1/providers/Microsoft.Storage/storageAccounts/account1"),
+			},
+			contains: "(deleted at 2024-01-02T03:04:05Z from /subscriptions/12345678-1234-9876-4563-123456789012/resourceGroups/resGroup1/providers/Microsoft.Storage/storageAccounts/account1)",
+		},
+	}
+
+	for _, v := range testData {
+		t.Logf("[DEBUG] Testing %q..", v.name)
+
+		if actual := storageAccountSoftDeletedMessage(v.input); !strings.Contains(actual, v.contains) {
+			t.Fatalf("expected the message to contain %q but got %q", v.contains, actual)
+		}
+	}
+}
+
+func TestFindDeletedStorageAccount(t *testing.T) {
+	deletedAccounts := []deletedaccounts.DeletedAccount{
+		{
+			Name: pointer.To("otheraccount"),
+			Properties: &deletedaccounts.DeletedAccountProperties{
+				Location: pointer.To("westeurope"),
+			},
+		},
+		{
+			Name: pointer.To("account1"),
+			Properties: &deletedaccounts.DeletedAccountProperties{
+				Location: pointer.To("eastus"),
+			},
+		},
+	}
+
+	testData := []struct {
+		name     string
+		input    []deletedaccounts.DeletedAccount
+		account  string
+		expected *string
+	}{
+		{
+			name:     "no deleted accounts",
+			input:    []deletedaccounts.DeletedAccount{},
+			account:  "account1",
+			expected: nil,
+		},
+		{
+			name:     "deleted in another region",
+			input:    deletedAccounts,
+			account:  "account1",
+			expected: pointer.To("eastus"),
+		},
+		{
+			name:     "different casing",
+			input:    deletedAccounts,
+			account:  "Account1",
+			expected: pointer.To("eastus"),
+		},
+		{
+			name:     "not deleted",
+			input:    deletedAccounts,
+			account:  "account2",
+			expected: nil,
+		},
+	}
+
+	for _, v := range testData {
+		t.Logf("[DEBUG] Testing %q..", v.name)
+
+		actual := findDeletedStorageAccount(v.input, v.account)
+		if v.expected == nil {
+			if actual != nil {
+				t.Fatalf("expected no deleted account but got %+v", actual)
+			}
+			continue
+		}
+		if actual == nil || actual.Properties == nil || pointer.From(actual.Properties.Location) != *v.expected {
+			t.Fatalf("expected the deleted account in %q but got %+v", *v.expected, actual)
+		}
+	}
+}
+
+type fakeStorageAccountProvisioningPoller struct {
+	statuses []pollers.PollingStatus
+	calls    int
+}
+
+func (p *fakeStorageAccountProvisioningPoller) Poll(_ context.Context) (*pollers.PollResult, error) {
+	status := p.statuses[p.calls]
+	p.calls++
+
+	if status == pollers.PollingStatusFailed {
+		return nil, pollers.PollingFailedError{
+			Message: "unexpected provisioningState \"Failed\"",
+		}
+	}
+
+	return &pollers.PollResult{
+		PollInterval: time.Millisecond,
+		Status:       status,
+	}, nil
+}
+
+func TestWaitForStorageAccountProvisioningUsingPoller(t *testing.T) {
+	id := commonids.NewStorageAccountID("12345678-1234-9876-4563-123456789012", "example-resources", "examplestorageacct")
+
+	testData := []struct {
+		name          string
+		statuses      []pollers.PollingStatus
+		expectedCalls int
+		expectError   bool
+	}{
+		{
+			name:          "already provisioned",
+			statuses:      []pollers.PollingStatus{pollers.PollingStatusSucceeded},
+			expectedCalls: 1,
+		},
+		{
+			name:          "provisioned after polling",
+			statuses:      []pollers.PollingStatus{pollers.PollingStatusInProgress, pollers.PollingStatusInProgress, pollers.PollingStatusSucceeded},
+			expectedCalls: 3,
+		},
+		{
+			name:          "provisioning failed",
+			statuses:      []pollers.PollingStatus{pollers.PollingStatusInProgress, pollers.PollingStatusFailed},
+			expectedCalls: 2,
+			expectError:   true,
+		},
+	}
+
+	for _, v := range testData {
+		t.Logf("[DEBUG] Testing %q..", v.name)
+
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		poller := &fakeStorageAccountProvisioningPoller{statuses: v.statuses}
+		err := waitForStorageAccountProvisioningUsingPoller(ctx, poller, id, time.Millisecond)
+		cancel()
+
+		if v.expectError && err == nil {
+			t.Fatalf("expected an error but didn't get one")
+		}
+		if !v.expectError && err != nil {
+			t.Fatalf("expected no error but got: %+v", err)
+		}
+		if poller.calls != v.expectedCalls {
+			t.Fatalf("expected %d calls to the poller but got %d", v.expectedCalls, poller.calls)
+		}
+	}
+}
+
+func TestAccountBlobRestorePolicyDisabled(t *testing.T) {
+	enabled := &blobservice.BlobServicePropertiesProperties{
+		RestorePolicy: &blobservice.RestorePolicyProperties{
+			Enabled: true,
+			Days:    pointer.To(int64(7)),
+		},
+	}
+	disabled := &blobservice.BlobServicePropertiesProperties{
+		RestorePolicy: &blobservice.RestorePolicyProperties{
+			Enabled: false,
+		},
+	}
+	omitted := &blobservice.BlobServicePropertiesProperties{}
+
+	testData := []struct {
+		name     string
+		existing *blobservice.BlobServicePropertiesProperties
+		desired  *blobservice.BlobServicePropertiesProperties
+		expected bool
+	}{
+		{
+			name:     "no existing properties",
+			desired:  disabled,
+			expected: false,
+		},
+		{
+			name:     "remains enabled",
+			existing: enabled,
+			desired:  enabled,
+			expected: false,
+		},
+		{
+			name:     "enabled",
+			existing: omitted,
+			desired:  enabled,
+			expected: false,
+		},
+		{
+			name:     "disabled",
+			existing: enabled,
+			desired:  disabled,
+			expected: true,
+		},
+		{
+			name:     "removed",
+			existing: enabled,
+			desired:  omitted,
+			expected: true,
+		},
+	}
+
+	for _, v := range testData {
+		t.Logf("[DEBUG] Testing %q..", v.name)
+
+		if actual := accountBlobRestorePolicyDisabled(v.existing, v.desired); actual != v.expected {
+			t.Fatalf("expected %t but got %t", v.expected, actual)
+		}
+	}
+}
+
+func TestAccountIdentityPrincipalIdPending(t *testing.T) {
+	testData := []struct {
+		name     string
+		input    *storageaccounts.StorageAccount
+		expected bool
+	}{
+		{
+			name:     "no model",
+			input:    nil,
+			expected: false,
+		},
+		{
+			name:     "no identity",
+			input:    &storageaccounts.StorageAccount{},
+			expected: false,
+		},
+		{
+			name: "user assigned",
+			input: &storageaccounts.StorageAccount{
+				Identity: &identity.LegacySystemAndUserAssignedMap{
+					Type: identity.TypeUserAssigned,
+				},
+			},
+			expected: false,
+		},
+		{
+			name: "system assigned pending",
+			input: &storageaccounts.StorageAccount{
+				Identity: &identity.LegacySystemAndUserAssignedMap{
+					Type: identity.TypeSystemAssigned,
+				},
+			},
+			expected: true,
+		},
+		{
+			name: "system and user assigned pending",
+			input: &storageaccounts.StorageAccount{
+				Identity: &identity.LegacySystemAndUserAssignedMap{
+					Type: identity.TypeSystemAssignedUserAssigned,
+				},
+			},
+			expected: true,
+		},
+		{
+			name: "system assigned populated",
+			input: &storageaccounts.StorageAccount{
+				Identity: &identity.LegacySystemAndUserAssignedMap{
+					Type:        identity.TypeSystemAssigned,
+					PrincipalId: "00000000-0000-0000-0000-000000000000",
+				},
+			},
+			expected: false,
+		},
+	}
+
+	for _, v := range testData {
+		t.Logf("[DEBUG] Testing %q..", v.name)
+
+		if actual := accountIdentityPrincipalIdPending(v.input); actual != v.expected {
+			t.Fatalf("expected %t but got %t", v.expected, actual)
+		}
+	}
+}
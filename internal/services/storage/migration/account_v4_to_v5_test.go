@@ -0,0 +1,87 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package migration
+
+import (
+	"context"
+	"reflect"
+	"testing"
+)
+
+func TestAccountV4ToV5(t *testing.T) {
+	testData := []struct {
+		name     string
+		input    map[string]interface{}
+		expected map[string]interface{}
+	}{
+		{
+			name: "legacy property only",
+			input: map[string]interface{}{
+				"name":                      "example",
+				"enable_https_traffic_only": false,
+			},
+			expected: map[string]interface{}{
+				"name":                       "example",
+				"https_traffic_only_enabled": false,
+			},
+		},
+		{
+			name: "both properties",
+			input: map[string]interface{}{
+				"name":                       "example",
+				"enable_https_traffic_only":  false,
+				"https_traffic_only_enabled": true,
+			},
+			expected: map[string]interface{}{
+				"name":                       "example",
+				"https_traffic_only_enabled": true,
+			},
+		},
+		{
+			name: "legacy property with a null replacement",
+			input: map[string]interface{}{
+				"name":                       "example",
+				"enable_https_traffic_only":  true,
+				"https_traffic_only_enabled": nil,
+			},
+			expected: map[string]interface{}{
+				"name":                       "example",
+				"https_traffic_only_enabled": true,
+			},
+		},
+		{
+			name: "neither property",
+			input: map[string]interface{}{
+				"name": "example",
+			},
+			expected: map[string]interface{}{
+				"name": "example",
+			},
+		},
+		{
+			name: "replacement property only",
+			input: map[string]interface{}{
+				"name":                       "example",
+				"https_traffic_only_enabled": false,
+			},
+			expected: map[string]interface{}{
+				"name":                       "example",
+				"https_traffic_only_enabled": false,
+			},
+		},
+	}
+
+	for _, v := range testData {
+		t.Logf("[DEBUG] Testing %q..", v.name)
+
+		actual, err := AccountV4ToV5{}.UpgradeFunc()(context.TODO(), v.input, nil)
+		if err != nil {
+			t.Fatalf("Expected no error but got: %s", err)
+		}
+
+		if !reflect.DeepEqual(v.expected, actual) {
+			t.Fatalf("Expected %+v. Got %+v. But expected them to be the same", v.expected, actual)
+		}
+	}
+}
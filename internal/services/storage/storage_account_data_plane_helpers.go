@@ -5,59 +5,75 @@ package storage
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"log"
-	"slices"
 	"time"
 
+	"github.com/hashicorp/go-azure-helpers/resourcemanager/commonids"
 	"github.com/hashicorp/go-azure-sdk/resource-manager/storage/2023-01-01/storageaccounts"
 	"github.com/hashicorp/go-azure-sdk/sdk/client/pollers"
 	"github.com/hashicorp/terraform-provider-azurerm/internal/services/storage/client"
 	"github.com/hashicorp/terraform-provider-azurerm/internal/services/storage/custompollers"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/services/storage/helpers"
 )
 
-type storageAccountServiceSupportLevel struct {
-	supportBlob          bool
-	supportQueue         bool
-	supportShare         bool
-	supportStaticWebsite bool
-}
+const (
+	findAccountAttempts = 3
+
+	// findAccountRetryInterval is the delay between attempts to locate a Storage Account which isn't (yet) returned from the List API
+	findAccountRetryInterval = 5 * time.Second
+)
+
+// findAccountForRead locates the Data Plane details for a Storage Account which GetProperties has already returned.
+// The List API used by FindAccount is eventually consistent, so shortly after creation the account may be missing -
+// in which case this retries a few times, before falling back to the endpoints within the GetProperties model.
+func findAccountForRead(ctx context.Context, storageClient *client.Client, id commonids.StorageAccountId, model *storageaccounts.StorageAccount) (*client.AccountDetails, error) {
+	for attempt := 1; attempt <= findAccountAttempts; attempt++ {
+		account, err := storageClient.FindAccount(ctx, id.SubscriptionId, id.StorageAccountName)
+		if err != nil {
+			return nil, fmt.Errorf("retrieving %s: %+v", id, err)
+		}
+		if account != nil {
+			return account, nil
+		}
 
-func availableFunctionalityForAccount(kind storageaccounts.Kind, tier storageaccounts.SkuTier, replicationType string) storageAccountServiceSupportLevel {
-	// FileStorage doesn't support blob
-	supportBlob := kind != storageaccounts.KindFileStorage
-
-	// Queue is only supported for Storage and StorageV2, in Standard sku tier.
-	supportQueue := tier == storageaccounts.SkuTierStandard && (kind == storageaccounts.KindStorageVTwo ||
-		(kind == storageaccounts.KindStorage &&
-			// Per local test, only LRS/GRS/RAGRS Storage V1 accounts support queue endpoint.
-			// GZRS and RAGZRS is invalid, while ZRS is valid but has no queue endpoint.
-			slices.Contains([]string{"LRS", "GRS", "RAGRS"}, replicationType)))
-
-	// File share is only supported for StorageV2 and FileStorage.
-	// See: https://docs.microsoft.com/en-us/azure/storage/files/storage-files-planning#management-concepts
-	// Per test, the StorageV2 with Premium sku tier also doesn't support file share.
-	supportShare := kind == storageaccounts.KindFileStorage || (tier != storageaccounts.SkuTierPremium && (kind == storageaccounts.KindStorageVTwo ||
-		(kind == storageaccounts.KindStorage &&
-			// Per local test, only LRS/GRS/RAGRS Storage V1 accounts support file endpoint.
-			// GZRS and RAGZRS is invalid, while ZRS is valid but has no file endpoint.
-			slices.Contains([]string{"LRS", "GRS", "RAGRS"}, replicationType))))
-
-	// Static Website is only supported for StorageV2 (not for Storage(v1)) and BlockBlobStorage
-	supportStaticWebSite := kind == storageaccounts.KindStorageVTwo || kind == storageaccounts.KindBlockBlobStorage
-
-	return storageAccountServiceSupportLevel{
-		supportBlob:          supportBlob,
-		supportQueue:         supportQueue,
-		supportShare:         supportShare,
-		supportStaticWebsite: supportStaticWebSite,
+		if attempt == findAccountAttempts {
+			break
+		}
+
+		log.Printf("[DEBUG] %s wasn't found in the List API (attempt %d of %d) - retrying in %s..", id, attempt, findAccountAttempts, findAccountRetryInterval)
+		select {
+		case <-ctx.Done():
+			return nil, fmt.Errorf("waiting to locate %s: %+v", id, ctx.Err())
+		case <-time.After(findAccountRetryInterval):
+		}
 	}
+
+	if model == nil {
+		return nil, fmt.Errorf("unable to locate %q", id)
+	}
+
+	log.Printf("[DEBUG] %s still wasn't found in the List API - using the endpoints from GetProperties", id)
+	if err := storageClient.AddToCache(id, *model); err != nil {
+		return nil, fmt.Errorf("populating cache for %s: %+v", id, err)
+	}
+
+	account, err := storageClient.FindAccount(ctx, id.SubscriptionId, id.StorageAccountName)
+	if err != nil {
+		return nil, fmt.Errorf("retrieving %s: %+v", id, err)
+	}
+	if account == nil {
+		return nil, fmt.Errorf("unable to locate %q", id)
+	}
+
+	return account, nil
 }
 
-func waitForDataPlaneToBecomeAvailableForAccount(ctx context.Context, client *client.Client, account *client.AccountDetails, supportLevel storageAccountServiceSupportLevel) error {
+func waitForDataPlaneToBecomeAvailableForAccount(ctx context.Context, client *client.Client, account *client.AccountDetails, supportLevel helpers.StorageAccountServiceSupportLevel) error {
 	initialDelayDuration := 10 * time.Second
 
-	if supportLevel.supportBlob {
+	if supportLevel.SupportBlob {
 		log.Printf("[DEBUG] waiting for the Blob Service to become available")
 		pollerType, err := custompollers.NewDataPlaneBlobContainersAvailabilityPoller(ctx, client, account)
 		if err != nil {
@@ -69,7 +85,7 @@ func waitForDataPlaneToBecomeAvailableForAccount(ctx context.Context, client *cl
 		}
 	}
 
-	if supportLevel.supportQueue {
+	if supportLevel.SupportQueue {
 		log.Printf("[DEBUG] waiting for the Queues Service to become available")
 		pollerType, err := custompollers.NewDataPlaneQueuesAvailabilityPoller(ctx, client, account)
 		if err != nil {
@@ -81,7 +97,7 @@ func waitForDataPlaneToBecomeAvailableForAccount(ctx context.Context, client *cl
 		}
 	}
 
-	if supportLevel.supportShare {
+	if supportLevel.SupportShare {
 		log.Printf("[DEBUG] waiting for the File Service to become available")
 		pollerType, err := custompollers.NewDataPlaneFileShareAvailabilityPoller(client, account)
 		if err != nil {
@@ -93,7 +109,7 @@ func waitForDataPlaneToBecomeAvailableForAccount(ctx context.Context, client *cl
 		}
 	}
 
-	if supportLevel.supportStaticWebsite {
+	if supportLevel.SupportStaticWebsite {
 		log.Printf("[DEBUG] waiting for the Static Website to become available")
 		pollerType, err := custompollers.NewDataPlaneStaticWebsiteAvailabilityPoller(ctx, client, account)
 		if err != nil {
@@ -107,3 +123,24 @@ func waitForDataPlaneToBecomeAvailableForAccount(ctx context.Context, client *cl
 
 	return nil
 }
+
+// storageAccountDataPlaneReadTimeoutDivisor controls the share of the remaining read timeout available to each Data Plane
+// call made when reading a Storage Account, so that a single unresponsive service can't consume the entire timeout.
+const storageAccountDataPlaneReadTimeoutDivisor = 4
+
+// dataPlaneReadContext returns a context for a single Data Plane call made when reading a Storage Account, bounded to
+// a share of the time remaining within the parent context.
+func dataPlaneReadContext(ctx context.Context) (context.Context, context.CancelFunc) {
+	deadline, ok := ctx.Deadline()
+	if !ok {
+		return context.WithCancel(ctx)
+	}
+
+	return context.WithTimeout(ctx, time.Until(deadline)/storageAccountDataPlaneReadTimeoutDivisor)
+}
+
+// dataPlaneReadTimedOut returns whether the Data Plane call using dataPlaneCtx timed out, whilst the parent context
+// (and as such, the overall read) still has time remaining.
+func dataPlaneReadTimedOut(ctx, dataPlaneCtx context.Context) bool {
+	return ctx.Err() == nil && errors.Is(dataPlaneCtx.Err(), context.DeadlineExceeded)
+}
@@ -0,0 +1,50 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package storage
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+func TestDataPlaneReadContext(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 4*time.Minute)
+	defer cancel()
+
+	dataPlaneCtx, dataPlaneCancel := dataPlaneReadContext(ctx)
+	defer dataPlaneCancel()
+
+	deadline, ok := dataPlaneCtx.Deadline()
+	if !ok {
+		t.Fatalf("expected the Data Plane context to have a deadline")
+	}
+	if remaining := time.Until(deadline); remaining > time.Minute || remaining < 50*time.Second {
+		t.Fatalf("expected the Data Plane context to have around 1m remaining but got %s", remaining)
+	}
+
+	noDeadlineCtx, noDeadlineCancel := dataPlaneReadContext(context.Background())
+	defer noDeadlineCancel()
+	if _, ok := noDeadlineCtx.Deadline(); ok {
+		t.Fatalf("expected the Data Plane context not to have a deadline when the parent context doesn't")
+	}
+}
+
+func TestDataPlaneReadTimedOut(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+	defer cancel()
+
+	dataPlaneCtx, dataPlaneCancel := context.WithTimeout(ctx, time.Nanosecond)
+	defer dataPlaneCancel()
+	<-dataPlaneCtx.Done()
+
+	if !dataPlaneReadTimedOut(ctx, dataPlaneCtx) {
+		t.Fatalf("expected the Data Plane call to have timed out")
+	}
+
+	cancel()
+	if dataPlaneReadTimedOut(ctx, dataPlaneCtx) {
+		t.Fatalf("expected the Data Plane call not to be reported as timed out once the parent context is done")
+	}
+}
@@ -6,7 +6,6 @@ package storage
 import (
 	"fmt"
 	"net/http"
-	"strings"
 	"time"
 
 	"github.com/hashicorp/go-azure-helpers/lang/pointer"
@@ -445,6 +444,11 @@ func dataSourceStorageAccount() *pluginsdk.Resource {
 				Sensitive: true,
 			},
 
+			"key_last_rotated": {
+				Type:     pluginsdk.TypeString,
+				Computed: true,
+			},
+
 			"primary_connection_string": {
 				Type:      pluginsdk.TypeString,
 				Computed:  true,
@@ -558,10 +562,8 @@ func dataSourceStorageAccountRead(d *pluginsdk.ResourceData, meta interface{}) e
 	ctx, cancel := timeouts.ForRead(meta.(*clients.Client).StopContext, d)
 	defer cancel()
 
-	storageDomainSuffix, ok := meta.(*clients.Client).Account.Environment.Storage.DomainSuffix()
-	if !ok {
-		return fmt.Errorf("could not determine Storage domain suffix for environment %q", meta.(*clients.Client).Account.Environment.Name)
-	}
+	// this takes into account the `storage_domain_suffix` override within the Provider block, if specified
+	storageDomainSuffix := meta.(*clients.Client).Storage.StorageDomainSuffix
 
 	id := commonids.NewStorageAccountID(subscriptionId, d.Get("resource_group_name").(string), d.Get("name").(string))
 	resp, err := client.GetProperties(ctx, id, storageaccounts.DefaultGetPropertiesOperationOptions())
@@ -573,16 +575,24 @@ func dataSourceStorageAccountRead(d *pluginsdk.ResourceData, meta interface{}) e
 		return fmt.Errorf("retrieving %s: %+v", id, err)
 	}
 
+	// the Principal ID of the System Assigned Identity can be empty when the Storage Account has only just been created,
+	// this is bounded within the read timeout
+	if resp.Model, err = waitForStorageAccountIdentityPrincipalId(ctx, client, id, resp.Model); err != nil {
+		return err
+	}
+
 	d.SetId(id.ID())
 
-	listKeysOpts := storageaccounts.DefaultListKeysOperationOptions()
-	listKeysOpts.Expand = pointer.To(storageaccounts.ListKeyExpandKerb)
-	keys, err := client.ListKeys(ctx, id, listKeysOpts)
-	if err != nil {
-		hasWriteLock := response.WasConflict(keys.HttpResponse)
-		doesntHavePermissions := response.WasForbidden(keys.HttpResponse) || response.WasStatusCode(keys.HttpResponse, http.StatusUnauthorized)
-		if !hasWriteLock && !doesntHavePermissions {
-			return fmt.Errorf("listing Keys for %s: %+v", id, err)
+	// the keys (and therefore the connection strings) are left empty when the `skip_key_retrieval` feature is enabled
+	var keys storageaccounts.ListKeysOperationResponse
+	if !meta.(*clients.Client).Features.Storage.SkipKeyRetrieval {
+		keys, err = client.ListKeys(ctx, id, storageAccountListKeysOptions(resp.Model))
+		if err != nil {
+			hasWriteLock := response.WasConflict(keys.HttpResponse)
+			doesntHavePermissions := response.WasForbidden(keys.HttpResponse) || response.WasStatusCode(keys.HttpResponse, http.StatusUnauthorized)
+			if !hasWriteLock && !doesntHavePermissions {
+				return fmt.Errorf("listing Keys for %s: %+v", id, err)
+			}
 		}
 	}
 
@@ -594,7 +604,7 @@ func dataSourceStorageAccountRead(d *pluginsdk.ResourceData, meta interface{}) e
 
 		if sku := model.Sku; sku != nil {
 			d.Set("account_tier", pointer.From(sku.Tier))
-			d.Set("account_replication_type", strings.Split(string(sku.Name), "_")[1])
+			d.Set("account_replication_type", storageAccountReplicationTypeFromSkuName(sku.Name))
 		}
 
 		flattenedIdentity, err := identity.FlattenLegacySystemAndUserAssignedMap(model.Identity)
@@ -647,11 +657,7 @@ func dataSourceStorageAccountRead(d *pluginsdk.ResourceData, meta interface{}) e
 			d.Set("table_encryption_key_type", tableEncryptionKeyType)
 
 			// For storage account created using old API, the response of GET call will not return "min_tls_version"
-			minTlsVersion := string(storageaccounts.MinimumTlsVersionTLSOneZero)
-			if props.MinimumTlsVersion != nil {
-				minTlsVersion = string(*props.MinimumTlsVersion)
-			}
-			d.Set("min_tls_version", minTlsVersion)
+			d.Set("min_tls_version", flattenAccountMinimumTlsVersion(props.MinimumTlsVersion))
 
 			// DNSEndpointType is null when unconfigured - therefore default this to Standard
 			dnsEndpointType := storageaccounts.DnsEndpointTypeStandard
@@ -669,7 +675,11 @@ func dataSourceStorageAccountRead(d *pluginsdk.ResourceData, meta interface{}) e
 	var primaryEndpoints *storageaccounts.Endpoints
 	var secondaryEndpoints *storageaccounts.Endpoints
 	var routingPreference *storageaccounts.RoutingPreference
+	sharedKeyAccessEnabled := true
 	if model := resp.Model; model != nil && model.Properties != nil {
+		if model.Properties.AllowSharedKeyAccess != nil {
+			sharedKeyAccessEnabled = *model.Properties.AllowSharedKeyAccess
+		}
 		primaryEndpoints = model.Properties.PrimaryEndpoints
 		routingPreference = model.Properties.RoutingPreference
 		secondaryEndpoints = model.Properties.SecondaryEndpoints
@@ -683,7 +693,7 @@ func dataSourceStorageAccountRead(d *pluginsdk.ResourceData, meta interface{}) e
 	if keys.Model != nil && keys.Model.Keys != nil {
 		storageAccountKeys = *keys.Model.Keys
 	}
-	keysAndConnectionStrings := flattenAccountAccessKeysAndConnectionStrings(id.StorageAccountName, *storageDomainSuffix, storageAccountKeys, endpoints)
+	keysAndConnectionStrings := flattenAccountAccessKeysAndConnectionStrings(id.StorageAccountName, storageDomainSuffix, sharedKeyAccessEnabled, storageAccountKeys, endpoints)
 	if err := keysAndConnectionStrings.set(d); err != nil {
 		return err
 	}
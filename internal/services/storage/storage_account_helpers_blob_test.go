@@ -0,0 +1,324 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package storage
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/go-azure-helpers/lang/pointer"
+	"github.com/hashicorp/go-azure-sdk/resource-manager/storage/2023-01-01/storageaccounts"
+)
+
+func TestExpandAccountBlobServicePropertiesChangeFeedRetention(t *testing.T) {
+	testData := []struct {
+		name              string
+		changeFeedEnabled bool
+		retentionInDays   int
+		expected          *int64
+		shouldError       bool
+	}{
+		{
+			name:              "change feed disabled",
+			changeFeedEnabled: false,
+		},
+		{
+			name:              "infinite retention when omitted",
+			changeFeedEnabled: true,
+		},
+		{
+			name:              "infinite retention when explicitly requested",
+			changeFeedEnabled: true,
+			retentionInDays:   -1,
+		},
+		{
+			name:              "retention in days",
+			changeFeedEnabled: true,
+			retentionInDays:   7,
+			expected:          pointer.To(int64(7)),
+		},
+		{
+			name:              "retention without the change feed",
+			changeFeedEnabled: false,
+			retentionInDays:   7,
+			shouldError:       true,
+		},
+		{
+			name:              "infinite retention without the change feed",
+			changeFeedEnabled: false,
+			retentionInDays:   -1,
+			shouldError:       true,
+		},
+	}
+
+	for _, v := range testData {
+		t.Logf("[DEBUG] Testing %q..", v.name)
+
+		input := []interface{}{
+			map[string]interface{}{
+				"change_feed_enabled":               v.changeFeedEnabled,
+				"change_feed_retention_in_days":     v.retentionInDays,
+				"container_delete_retention_policy": []interface{}{},
+				"cors_rule":                         []interface{}{},
+				"default_service_version":           "",
+				"delete_retention_policy":           []interface{}{},
+				"last_access_time_enabled":          false,
+				"restore_policy":                    []interface{}{},
+				"versioning_enabled":                false,
+			},
+		}
+
+		actual, err := expandAccountBlobServiceProperties(storageaccounts.KindStorageVTwo, input)
+		if v.shouldError {
+			if err == nil {
+				t.Fatalf("expected an error but didn't get one")
+			}
+			continue
+		}
+		if err != nil {
+			t.Fatalf("expected no error but got: %+v", err)
+		}
+
+		changeFeed := actual.Properties.ChangeFeed
+		if changeFeed == nil || changeFeed.Enabled == nil || *changeFeed.Enabled != v.changeFeedEnabled {
+			t.Fatalf("expected the change feed to be enabled %t but got %+v", v.changeFeedEnabled, changeFeed)
+		}
+
+		if v.expected == nil {
+			if changeFeed.RetentionInDays != nil {
+				t.Fatalf("expected no retention but got %d", *changeFeed.RetentionInDays)
+			}
+			continue
+		}
+		if changeFeed.RetentionInDays == nil || *changeFeed.RetentionInDays != *v.expected {
+			t.Fatalf("expected a retention of %d but got %+v", *v.expected, changeFeed.RetentionInDays)
+		}
+	}
+}
+
+func TestValidateAccountBlobPropertiesForDnsEndpointType(t *testing.T) {
+	testData := []struct {
+		name            string
+		dnsEndpointType storageaccounts.DnsEndpointType
+		input           []interface{}
+		shouldError     bool
+	}{
+		{
+			name:            "standard dns with restore policy",
+			dnsEndpointType: storageaccounts.DnsEndpointTypeStandard,
+			input: []interface{}{
+				map[string]interface{}{
+					"restore_policy": []interface{}{
+						map[string]interface{}{"days": 7},
+					},
+				},
+			},
+		},
+		{
+			name:            "azure dns zone without blob properties",
+			dnsEndpointType: storageaccounts.DnsEndpointTypeAzureDnsZone,
+			input:           []interface{}{},
+		},
+		{
+			name:            "azure dns zone without restore policy",
+			dnsEndpointType: storageaccounts.DnsEndpointTypeAzureDnsZone,
+			input: []interface{}{
+				map[string]interface{}{
+					"restore_policy":     []interface{}{},
+					"versioning_enabled": true,
+				},
+			},
+		},
+		{
+			name:            "azure dns zone with restore policy",
+			dnsEndpointType: storageaccounts.DnsEndpointTypeAzureDnsZone,
+			input: []interface{}{
+				map[string]interface{}{
+					"restore_policy": []interface{}{
+						map[string]interface{}{"days": 7},
+					},
+				},
+			},
+			shouldError: true,
+		},
+	}
+
+	for _, v := range testData {
+		t.Logf("[DEBUG] Testing %q..", v.name)
+
+		err := validateAccountBlobPropertiesForDnsEndpointType(v.dnsEndpointType, v.input)
+		if v.shouldError && err == nil {
+			t.Fatalf("expected an error but didn't get one")
+		}
+		if !v.shouldError && err != nil {
+			t.Fatalf("expected no error but got: %+v", err)
+		}
+	}
+}
+
+func TestAccountHasImmutabilityPolicy(t *testing.T) {
+	testData := []struct {
+		name     string
+		input    *storageaccounts.ImmutableStorageAccount
+		expected bool
+	}{
+		{
+			name:     "not configured",
+			input:    nil,
+			expected: false,
+		},
+		{
+			name: "disabled",
+			input: &storageaccounts.ImmutableStorageAccount{
+				Enabled: pointer.To(false),
+			},
+			expected: false,
+		},
+		{
+			name: "enabled without a policy",
+			input: &storageaccounts.ImmutableStorageAccount{
+				Enabled: pointer.To(true),
+			},
+			expected: true,
+		},
+		{
+			name: "policy configured",
+			input: &storageaccounts.ImmutableStorageAccount{
+				Enabled: pointer.To(true),
+				ImmutabilityPolicy: &storageaccounts.AccountImmutabilityPolicyProperties{
+					ImmutabilityPeriodSinceCreationInDays: pointer.To(int64(7)),
+					State:                                 pointer.To(storageaccounts.AccountImmutabilityPolicyStateUnlocked),
+				},
+			},
+			expected: true,
+		},
+	}
+
+	for _, v := range testData {
+		t.Logf("[DEBUG] Testing %q..", v.name)
+
+		if actual := accountHasImmutabilityPolicy(v.input); actual != v.expected {
+			t.Fatalf("expected %t but got %t", v.expected, actual)
+		}
+	}
+}
+
+func TestValidateAccountBlobPropertiesDependencies(t *testing.T) {
+	restorePolicy := []interface{}{
+		map[string]interface{}{
+			"days": 7,
+		},
+	}
+
+	testData := []struct {
+		name        string
+		input       []interface{}
+		expectError string
+	}{
+		{
+			name:  "omitted",
+			input: []interface{}{},
+		},
+		{
+			name: "versioning and change feed",
+			input: []interface{}{
+				map[string]interface{}{
+					"versioning_enabled":  true,
+					"change_feed_enabled": true,
+					"restore_policy":      restorePolicy,
+				},
+			},
+		},
+		{
+			name: "change feed without versioning",
+			input: []interface{}{
+				map[string]interface{}{
+					"versioning_enabled":  false,
+					"change_feed_enabled": true,
+				},
+			},
+		},
+		{
+			name: "restore policy without versioning",
+			input: []interface{}{
+				map[string]interface{}{
+					"versioning_enabled":  false,
+					"change_feed_enabled": true,
+					"restore_policy":      restorePolicy,
+				},
+			},
+			expectError: "`versioning_enabled` must also be set",
+		},
+		{
+			name: "restore policy without either",
+			input: []interface{}{
+				map[string]interface{}{
+					"versioning_enabled":  false,
+					"change_feed_enabled": false,
+					"restore_policy":      restorePolicy,
+				},
+			},
+			expectError: "`versioning_enabled` and `change_feed_enabled` must also be set",
+		},
+	}
+
+	for _, v := range testData {
+		t.Logf("[DEBUG] Testing %q..", v.name)
+
+		err := validateAccountBlobPropertiesDependencies(v.input)
+		if v.expectError != "" {
+			if err == nil || !strings.Contains(err.Error(), v.expectError) {
+				t.Fatalf("expected an error containing %q but got: %+v", v.expectError, err)
+			}
+			continue
+		}
+		if err != nil {
+			t.Fatalf("expected no error but got: %+v", err)
+		}
+	}
+}
+
+func TestAccountHnsRequiredFieldsViolations(t *testing.T) {
+	testData := []struct {
+		name       string
+		hnsEnabled bool
+		enabled    map[string]bool
+		expected   []string
+	}{
+		{
+			name:       "hns enabled",
+			hnsEnabled: true,
+			enabled:    map[string]bool{"nfsv3_enabled": true, "sftp_enabled": true},
+			expected:   []string{},
+		},
+		{
+			name:       "nothing enabled",
+			hnsEnabled: false,
+			enabled:    map[string]bool{"nfsv3_enabled": false, "sftp_enabled": false},
+			expected:   []string{},
+		},
+		{
+			name:       "sftp enabled",
+			hnsEnabled: false,
+			enabled:    map[string]bool{"sftp_enabled": true},
+			expected:   []string{"`sftp_enabled`"},
+		},
+		{
+			name:       "all enabled",
+			hnsEnabled: false,
+			enabled:    map[string]bool{"nfsv3_enabled": true, "sftp_enabled": true},
+			expected:   []string{"`nfsv3_enabled`", "`sftp_enabled`"},
+		},
+	}
+
+	for _, v := range testData {
+		t.Logf("[DEBUG] Testing %q..", v.name)
+
+		actual := accountHnsRequiredFieldsViolations(v.hnsEnabled, v.enabled)
+		if !reflect.DeepEqual(actual, v.expected) {
+			t.Fatalf("expected %+v but got %+v", v.expected, actual)
+		}
+	}
+}
@@ -6,6 +6,7 @@ package storage
 import (
 	"fmt"
 	"net/url"
+	"time"
 
 	"github.com/hashicorp/go-azure-helpers/lang/pointer"
 	"github.com/hashicorp/go-azure-sdk/resource-manager/storage/2023-01-01/storageaccounts"
@@ -13,6 +14,10 @@ import (
 )
 
 type accountEndpoints struct {
+	// internetEndpointsPublished specifies whether the Routing Preference publishes the Internet Routing endpoints,
+	// when it doesn't the `*_internet_endpoint` and `*_internet_host` attributes are left unset
+	internetEndpointsPublished bool
+
 	primaryBlobEndpoint            string
 	primaryBlobHostName            string
 	primaryBlobInternetEndpoint    string
@@ -87,40 +92,28 @@ type accountEndpoints struct {
 func (a accountEndpoints) set(d *pluginsdk.ResourceData) error {
 	d.Set("primary_blob_endpoint", a.primaryBlobEndpoint)
 	d.Set("primary_blob_host", a.primaryBlobHostName)
-	d.Set("primary_blob_internet_endpoint", a.primaryBlobInternetEndpoint)
-	d.Set("primary_blob_internet_host", a.primaryBlobInternetHostName)
 	d.Set("primary_blob_microsoft_endpoint", a.primaryBlobMicrosoftEndpoint)
 	d.Set("primary_blob_microsoft_host", a.primaryBlobMicrosoftHostName)
 	d.Set("secondary_blob_endpoint", a.secondaryBlobEndpoint)
 	d.Set("secondary_blob_host", a.secondaryBlobHostName)
-	d.Set("secondary_blob_internet_endpoint", a.secondaryBlobInternetEndpoint)
-	d.Set("secondary_blob_internet_host", a.secondaryBlobInternetHostName)
 	d.Set("secondary_blob_microsoft_endpoint", a.secondaryBlobMicrosoftEndpoint)
 	d.Set("secondary_blob_microsoft_host", a.secondaryBlobMicrosoftHostName)
 
 	d.Set("primary_dfs_endpoint", a.primaryDfsEndpoint)
 	d.Set("primary_dfs_host", a.primaryDfsHostName)
-	d.Set("primary_dfs_internet_endpoint", a.primaryDfsInternetEndpoint)
-	d.Set("primary_dfs_internet_host", a.primaryDfsInternetHostName)
 	d.Set("primary_dfs_microsoft_endpoint", a.primaryDfsMicrosoftEndpoint)
 	d.Set("primary_dfs_microsoft_host", a.primaryDfsMicrosoftHostName)
 	d.Set("secondary_dfs_endpoint", a.secondaryDfsEndpoint)
 	d.Set("secondary_dfs_host", a.secondaryDfsHostName)
-	d.Set("secondary_dfs_internet_endpoint", a.secondaryDfsInternetEndpoint)
-	d.Set("secondary_dfs_internet_host", a.secondaryDfsInternetHostName)
 	d.Set("secondary_dfs_microsoft_endpoint", a.secondaryDfsMicrosoftEndpoint)
 	d.Set("secondary_dfs_microsoft_host", a.secondaryDfsMicrosoftHostName)
 
 	d.Set("primary_file_endpoint", a.primaryFileEndpoint)
 	d.Set("primary_file_host", a.primaryFileHostName)
-	d.Set("primary_file_internet_endpoint", a.primaryFileInternetEndpoint)
-	d.Set("primary_file_internet_host", a.primaryFileInternetHostName)
 	d.Set("primary_file_microsoft_endpoint", a.primaryFileMicrosoftEndpoint)
 	d.Set("primary_file_microsoft_host", a.primaryFileMicrosoftHostName)
 	d.Set("secondary_file_endpoint", a.secondaryFileEndpoint)
 	d.Set("secondary_file_host", a.secondaryFileHostName)
-	d.Set("secondary_file_internet_endpoint", a.secondaryFileInternetEndpoint)
-	d.Set("secondary_file_internet_host", a.secondaryFileInternetHostName)
 	d.Set("secondary_file_microsoft_endpoint", a.secondaryFileMicrosoftEndpoint)
 	d.Set("secondary_file_microsoft_host", a.secondaryFileMicrosoftHostName)
 
@@ -148,18 +141,43 @@ func (a accountEndpoints) set(d *pluginsdk.ResourceData) error {
 	d.Set("secondary_web_host", a.secondaryWebHostName)
 	d.Set("primary_web_microsoft_endpoint", a.primaryWebMicrosoftEndpoint)
 	d.Set("primary_web_microsoft_host", a.primaryWebMicrosoftHostName)
-	d.Set("primary_web_internet_endpoint", a.primaryWebInternetEndpoint)
-	d.Set("primary_web_internet_host", a.primaryWebInternetHostName)
-	d.Set("secondary_web_internet_endpoint", a.secondaryWebInternetEndpoint)
-	d.Set("secondary_web_internet_host", a.secondaryWebInternetHostName)
 	d.Set("secondary_web_microsoft_endpoint", a.secondaryWebMicrosoftEndpoint)
 	d.Set("secondary_web_microsoft_host", a.secondaryWebMicrosoftHostName)
 
+	// the Internet Routing endpoints are only available when these are published by the Routing Preference
+	internetEndpoints := map[string]string{
+		"primary_blob_internet_endpoint":   a.primaryBlobInternetEndpoint,
+		"primary_blob_internet_host":       a.primaryBlobInternetHostName,
+		"secondary_blob_internet_endpoint": a.secondaryBlobInternetEndpoint,
+		"secondary_blob_internet_host":     a.secondaryBlobInternetHostName,
+		"primary_dfs_internet_endpoint":    a.primaryDfsInternetEndpoint,
+		"primary_dfs_internet_host":        a.primaryDfsInternetHostName,
+		"secondary_dfs_internet_endpoint":  a.secondaryDfsInternetEndpoint,
+		"secondary_dfs_internet_host":      a.secondaryDfsInternetHostName,
+		"primary_file_internet_endpoint":   a.primaryFileInternetEndpoint,
+		"primary_file_internet_host":       a.primaryFileInternetHostName,
+		"secondary_file_internet_endpoint": a.secondaryFileInternetEndpoint,
+		"secondary_file_internet_host":     a.secondaryFileInternetHostName,
+		"primary_web_internet_endpoint":    a.primaryWebInternetEndpoint,
+		"primary_web_internet_host":        a.primaryWebInternetHostName,
+		"secondary_web_internet_endpoint":  a.secondaryWebInternetEndpoint,
+		"secondary_web_internet_host":      a.secondaryWebInternetHostName,
+	}
+	for k, v := range internetEndpoints {
+		if a.internetEndpointsPublished {
+			d.Set(k, v)
+		} else {
+			d.Set(k, nil)
+		}
+	}
+
 	return nil
 }
 
 func flattenAccountEndpoints(primaryEndpoints, secondaryEndpoints *storageaccounts.Endpoints, routingPreference *storageaccounts.RoutingPreference) accountEndpoints {
-	output := accountEndpoints{}
+	output := accountEndpoints{
+		internetEndpointsPublished: routingPreference != nil && pointer.From(routingPreference.PublishInternetEndpoints),
+	}
 
 	if primaryEndpoints != nil {
 		output.primaryBlobEndpoint, output.primaryBlobHostName = flattenAccountEndpointAndHost(primaryEndpoints.Blob)
@@ -237,6 +255,9 @@ type accountAccessKeysAndConnectionStrings struct {
 	secondaryBlobConnectionString string
 	primaryAccessKey              string
 	secondaryAccessKey            string
+
+	// keyLastRotated is the most recent Creation Time of the Access Keys, which changes when either key is rotated
+	keyLastRotated string
 }
 
 func (a accountAccessKeysAndConnectionStrings) set(d *pluginsdk.ResourceData) error {
@@ -246,11 +267,12 @@ func (a accountAccessKeysAndConnectionStrings) set(d *pluginsdk.ResourceData) er
 	d.Set("secondary_blob_connection_string", a.secondaryBlobConnectionString)
 	d.Set("primary_access_key", a.primaryAccessKey)
 	d.Set("secondary_access_key", a.secondaryAccessKey)
+	d.Set("key_last_rotated", a.keyLastRotated)
 
 	return nil
 }
 
-func flattenAccountAccessKeysAndConnectionStrings(accountName, domainSuffix string, keys []storageaccounts.StorageAccountKey, endpoints accountEndpoints) accountAccessKeysAndConnectionStrings {
+func flattenAccountAccessKeysAndConnectionStrings(accountName, domainSuffix string, sharedKeyAccessEnabled bool, keys []storageaccounts.StorageAccountKey, endpoints accountEndpoints) accountAccessKeysAndConnectionStrings {
 	output := accountAccessKeysAndConnectionStrings{}
 
 	// NOTE: users might not have access to list the keys, which is handled in the Data Source (optional) / Resource (required) respectively
@@ -259,23 +281,60 @@ func flattenAccountAccessKeysAndConnectionStrings(accountName, domainSuffix stri
 		if len(keys) > 1 {
 			output.secondaryAccessKey = pointer.From(keys[1].Value)
 		}
+		output.keyLastRotated = flattenAccountKeyLastRotated(keys)
+	}
 
-		if output.primaryAccessKey != "" {
-			output.primaryConnectionString = fmt.Sprintf("DefaultEndpointsProtocol=https;AccountName=%s;AccountKey=%s;EndpointSuffix=%s", accountName, output.primaryAccessKey, domainSuffix)
+	// when Shared Key access is disabled the Access Keys can't be used to authenticate, so instead we expose
+	// connection strings without an `AccountKey`, which can be used alongside a Microsoft Entra ID (OAuth) token
+	if !sharedKeyAccessEnabled {
+		output.primaryConnectionString = fmt.Sprintf("DefaultEndpointsProtocol=https;AccountName=%s;EndpointSuffix=%s", accountName, domainSuffix)
+		if endpoints.primaryBlobEndpoint != "" {
+			output.primaryBlobConnectionString = fmt.Sprintf("DefaultEndpointsProtocol=https;BlobEndpoint=%s;AccountName=%s", endpoints.primaryBlobEndpoint, accountName)
+		}
+		if endpoints.secondaryBlobEndpoint != "" {
+			output.secondaryConnectionString = fmt.Sprintf("DefaultEndpointsProtocol=https;AccountName=%s;EndpointSuffix=%s", accountName, domainSuffix)
+			output.secondaryBlobConnectionString = fmt.Sprintf("DefaultEndpointsProtocol=https;BlobEndpoint=%s;AccountName=%s", endpoints.secondaryBlobEndpoint, accountName)
+		}
 
-			if endpoints.primaryBlobEndpoint != "" {
-				output.primaryBlobConnectionString = fmt.Sprintf("DefaultEndpointsProtocol=https;BlobEndpoint=%s;AccountName=%s;AccountKey=%s", endpoints.primaryBlobEndpoint, accountName, output.primaryAccessKey)
-			}
+		return output
+	}
+
+	if output.primaryAccessKey != "" {
+		output.primaryConnectionString = fmt.Sprintf("DefaultEndpointsProtocol=https;AccountName=%s;AccountKey=%s;EndpointSuffix=%s", accountName, output.primaryAccessKey, domainSuffix)
+
+		if endpoints.primaryBlobEndpoint != "" {
+			output.primaryBlobConnectionString = fmt.Sprintf("DefaultEndpointsProtocol=https;BlobEndpoint=%s;AccountName=%s;AccountKey=%s", endpoints.primaryBlobEndpoint, accountName, output.primaryAccessKey)
 		}
+	}
 
-		if output.secondaryAccessKey != "" {
-			output.secondaryConnectionString = fmt.Sprintf("DefaultEndpointsProtocol=https;AccountName=%s;AccountKey=%s;EndpointSuffix=%s", accountName, output.secondaryAccessKey, domainSuffix)
+	if output.secondaryAccessKey != "" {
+		output.secondaryConnectionString = fmt.Sprintf("DefaultEndpointsProtocol=https;AccountName=%s;AccountKey=%s;EndpointSuffix=%s", accountName, output.secondaryAccessKey, domainSuffix)
 
-			if endpoints.secondaryBlobEndpoint != "" {
-				output.secondaryBlobConnectionString = fmt.Sprintf("DefaultEndpointsProtocol=https;BlobEndpoint=%s;AccountName=%s;AccountKey=%s", endpoints.secondaryBlobEndpoint, accountName, output.secondaryAccessKey)
-			}
+		if endpoints.secondaryBlobEndpoint != "" {
+			output.secondaryBlobConnectionString = fmt.Sprintf("DefaultEndpointsProtocol=https;BlobEndpoint=%s;AccountName=%s;AccountKey=%s", endpoints.secondaryBlobEndpoint, accountName, output.secondaryAccessKey)
 		}
 	}
 
 	return output
 }
+
+// flattenAccountKeyLastRotated returns the most recent Creation Time of the primary/secondary Access Keys - any Kerberos
+// keys are listed after these and so are ignored.
+func flattenAccountKeyLastRotated(keys []storageaccounts.StorageAccountKey) string {
+	var lastRotated *time.Time
+	for i := 0; i < len(keys) && i < 2; i++ {
+		created, err := keys[i].GetCreationTimeAsTime()
+		if err != nil || created == nil {
+			continue
+		}
+		if lastRotated == nil || created.After(*lastRotated) {
+			lastRotated = created
+		}
+	}
+
+	if lastRotated == nil {
+		return ""
+	}
+
+	return lastRotated.Format(time.RFC3339)
+}
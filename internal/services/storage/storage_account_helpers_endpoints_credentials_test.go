@@ -0,0 +1,190 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package storage
+
+import (
+	"testing"
+
+	"github.com/hashicorp/go-azure-helpers/lang/pointer"
+	"github.com/hashicorp/go-azure-sdk/resource-manager/storage/2023-01-01/storageaccounts"
+)
+
+func TestFlattenAccountEndpointsInternetRouting(t *testing.T) {
+	primaryEndpoints := &storageaccounts.Endpoints{
+		Blob: pointer.To("https://example.blob.core.windows.net/"),
+		InternetEndpoints: &storageaccounts.StorageAccountInternetEndpoints{
+			Blob: pointer.To("https://example-internetrouting.blob.core.windows.net/"),
+			Dfs:  pointer.To("https://example-internetrouting.dfs.core.windows.net/"),
+			File: pointer.To("https://example-internetrouting.file.core.windows.net/"),
+			Web:  pointer.To("https://example-internetrouting.z6.web.core.windows.net/"),
+		},
+	}
+	secondaryEndpoints := &storageaccounts.Endpoints{
+		Blob: pointer.To("https://example-secondary.blob.core.windows.net/"),
+		InternetEndpoints: &storageaccounts.StorageAccountInternetEndpoints{
+			Blob: pointer.To("https://example-internetrouting-secondary.blob.core.windows.net/"),
+		},
+	}
+
+	testData := []struct {
+		name              string
+		routingPreference *storageaccounts.RoutingPreference
+		expectPublished   bool
+	}{
+		{
+			name:              "no routing preference",
+			routingPreference: nil,
+			expectPublished:   false,
+		},
+		{
+			name: "internet endpoints unpublished",
+			routingPreference: &storageaccounts.RoutingPreference{
+				PublishInternetEndpoints:  pointer.To(false),
+				PublishMicrosoftEndpoints: pointer.To(true),
+			},
+			expectPublished: false,
+		},
+		{
+			name: "internet endpoints published",
+			routingPreference: &storageaccounts.RoutingPreference{
+				PublishInternetEndpoints: pointer.To(true),
+			},
+			expectPublished: true,
+		},
+	}
+
+	for _, v := range testData {
+		t.Logf("[DEBUG] Testing %q", v.name)
+
+		actual := flattenAccountEndpoints(primaryEndpoints, secondaryEndpoints, v.routingPreference)
+		if actual.internetEndpointsPublished != v.expectPublished {
+			t.Fatalf("Expected `internetEndpointsPublished` to be %t but got %t", v.expectPublished, actual.internetEndpointsPublished)
+		}
+		if actual.primaryBlobEndpoint != "https://example.blob.core.windows.net/" {
+			t.Fatalf("Expected the Primary Blob Endpoint to be populated but got %q", actual.primaryBlobEndpoint)
+		}
+
+		if v.expectPublished {
+			if actual.primaryBlobInternetEndpoint != "https://example-internetrouting.blob.core.windows.net/" {
+				t.Fatalf("Expected the Primary Blob Internet Endpoint to be populated but got %q", actual.primaryBlobInternetEndpoint)
+			}
+			if actual.primaryWebInternetHostName != "example-internetrouting.z6.web.core.windows.net" {
+				t.Fatalf("Expected the Primary Web Internet Host to be populated but got %q", actual.primaryWebInternetHostName)
+			}
+			if actual.secondaryBlobInternetHostName != "example-internetrouting-secondary.blob.core.windows.net" {
+				t.Fatalf("Expected the Secondary Blob Internet Host to be populated but got %q", actual.secondaryBlobInternetHostName)
+			}
+			continue
+		}
+
+		if actual.primaryBlobInternetEndpoint != "" || actual.primaryDfsInternetEndpoint != "" || actual.secondaryBlobInternetEndpoint != "" {
+			t.Fatalf("Expected the Internet Endpoints to be unset but got %+v", actual)
+		}
+	}
+}
+
+func TestFlattenAccountAccessKeysAndConnectionStringsSharedKeyAccessDisabled(t *testing.T) {
+	keys := []storageaccounts.StorageAccountKey{
+		{
+			Value: pointer.To("primary"),
+		},
+		{
+			Value: pointer.To("secondary"),
+		},
+	}
+
+	testData := []struct {
+		name                            string
+		endpoints                       accountEndpoints
+		expectedSecondaryConnection     string
+		expectedSecondaryBlobConnection string
+	}{
+		{
+			name: "locally redundant",
+			endpoints: accountEndpoints{
+				primaryBlobEndpoint: "https://example.blob.core.windows.net/",
+			},
+			expectedSecondaryConnection:     "",
+			expectedSecondaryBlobConnection: "",
+		},
+		{
+			name: "geo redundant",
+			endpoints: accountEndpoints{
+				primaryBlobEndpoint:   "https://example.blob.core.windows.net/",
+				secondaryBlobEndpoint: "https://example-secondary.blob.core.windows.net/",
+			},
+			expectedSecondaryConnection:     "DefaultEndpointsProtocol=https;AccountName=example;EndpointSuffix=core.windows.net",
+			expectedSecondaryBlobConnection: "DefaultEndpointsProtocol=https;BlobEndpoint=https://example-secondary.blob.core.windows.net/;AccountName=example",
+		},
+	}
+
+	for _, v := range testData {
+		t.Logf("[DEBUG] Testing %q..", v.name)
+
+		actual := flattenAccountAccessKeysAndConnectionStrings("example", "core.windows.net", false, keys, v.endpoints)
+		if actual.primaryConnectionString != "DefaultEndpointsProtocol=https;AccountName=example;EndpointSuffix=core.windows.net" {
+			t.Fatalf("expected a keyless Primary Connection String but got %q", actual.primaryConnectionString)
+		}
+		if actual.secondaryConnectionString != v.expectedSecondaryConnection {
+			t.Fatalf("expected the Secondary Connection String to be %q but got %q", v.expectedSecondaryConnection, actual.secondaryConnectionString)
+		}
+		if actual.secondaryBlobConnectionString != v.expectedSecondaryBlobConnection {
+			t.Fatalf("expected the Secondary Blob Connection String to be %q but got %q", v.expectedSecondaryBlobConnection, actual.secondaryBlobConnectionString)
+		}
+	}
+}
+
+func TestFlattenAccountKeyLastRotated(t *testing.T) {
+	testData := []struct {
+		name     string
+		input    []storageaccounts.StorageAccountKey
+		expected string
+	}{
+		{
+			name:     "no keys",
+			expected: "",
+		},
+		{
+			name: "no creation times",
+			input: []storageaccounts.StorageAccountKey{
+				{KeyName: pointer.To("key1")},
+				{KeyName: pointer.To("key2")},
+			},
+			expected: "",
+		},
+		{
+			name: "secondary key rotated most recently",
+			input: []storageaccounts.StorageAccountKey{
+				{KeyName: pointer.To("key1"), CreationTime: pointer.To("2024-01-02T03:04:05Z")},
+				{KeyName: pointer.To("key2"), CreationTime: pointer.To("2024-06-07T08:09:10Z")},
+			},
+			expected: "2024-06-07T08:09:10Z",
+		},
+		{
+			name: "primary key rotated most recently",
+			input: []storageaccounts.StorageAccountKey{
+				{KeyName: pointer.To("key1"), CreationTime: pointer.To("2024-06-07T08:09:10Z")},
+				{KeyName: pointer.To("key2"), CreationTime: pointer.To("2024-01-02T03:04:05Z")},
+			},
+			expected: "2024-06-07T08:09:10Z",
+		},
+		{
+			name: "kerberos keys are ignored",
+			input: []storageaccounts.StorageAccountKey{
+				{KeyName: pointer.To("key1"), CreationTime: pointer.To("2024-01-02T03:04:05Z")},
+				{KeyName: pointer.To("key2"), CreationTime: pointer.To("2024-01-02T03:04:05Z")},
+				{KeyName: pointer.To("kerb1"), CreationTime: pointer.To("2024-06-07T08:09:10Z")},
+			},
+			expected: "2024-01-02T03:04:05Z",
+		},
+	}
+
+	for _, v := range testData {
+		t.Logf("[DEBUG] Testing %q..", v.name)
+
+		if actual := flattenAccountKeyLastRotated(v.input); actual != v.expected {
+			t.Fatalf("expected %q but got %q", v.expected, actual)
+		}
+	}
+}
@@ -0,0 +1,146 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package storage
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/hashicorp/go-azure-helpers/lang/pointer"
+	"github.com/hashicorp/go-azure-sdk/resource-manager/storage/2023-01-01/storageaccounts"
+)
+
+func TestExpandAccountAzureFilesAuthentication(t *testing.T) {
+	activeDirectory := func(storageSid string) []interface{} {
+		return []interface{}{
+			map[string]interface{}{
+				"domain_guid":         "13a20c9a-d491-47e6-8a39-299e7a32ea27",
+				"domain_name":         "adtest.com",
+				"storage_sid":         storageSid,
+				"domain_sid":          "S-1-5-21-2400535526-2334094090-2402026252",
+				"forest_name":         "adtest.com",
+				"netbios_domain_name": "adtest.com",
+			},
+		}
+	}
+
+	testData := []struct {
+		name     string
+		input    []interface{}
+		expected *storageaccounts.AzureFilesIdentityBasedAuthentication
+		error    bool
+	}{
+		{
+			name: "not configured",
+			expected: &storageaccounts.AzureFilesIdentityBasedAuthentication{
+				DirectoryServiceOptions: storageaccounts.DirectoryServiceOptionsNone,
+			},
+		},
+		{
+			name: "AADKERB without an active_directory block",
+			input: []interface{}{
+				map[string]interface{}{
+					"directory_type":                 string(storageaccounts.DirectoryServiceOptionsAADKERB),
+					"active_directory":               []interface{}{},
+					"default_share_level_permission": string(storageaccounts.DefaultSharePermissionStorageFileDataSmbShareReader),
+				},
+			},
+			expected: &storageaccounts.AzureFilesIdentityBasedAuthentication{
+				DirectoryServiceOptions: storageaccounts.DirectoryServiceOptionsAADKERB,
+				DefaultSharePermission:  pointer.To(storageaccounts.DefaultSharePermissionStorageFileDataSmbShareReader),
+			},
+		},
+		{
+			name: "AADKERB with only the domain details",
+			input: []interface{}{
+				map[string]interface{}{
+					"directory_type": string(storageaccounts.DirectoryServiceOptionsAADKERB),
+					"active_directory": []interface{}{
+						map[string]interface{}{
+							"domain_guid":         "13a20c9a-d491-47e6-8a39-299e7a32ea27",
+							"domain_name":         "adtest.com",
+							"storage_sid":         "",
+							"domain_sid":          "",
+							"forest_name":         "",
+							"netbios_domain_name": "",
+						},
+					},
+					"default_share_level_permission": string(storageaccounts.DefaultSharePermissionNone),
+				},
+			},
+			expected: &storageaccounts.AzureFilesIdentityBasedAuthentication{
+				DirectoryServiceOptions: storageaccounts.DirectoryServiceOptionsAADKERB,
+				ActiveDirectoryProperties: &storageaccounts.ActiveDirectoryProperties{
+					DomainGuid: "13a20c9a-d491-47e6-8a39-299e7a32ea27",
+					DomainName: "adtest.com",
+				},
+				DefaultSharePermission: pointer.To(storageaccounts.DefaultSharePermissionNone),
+			},
+		},
+		{
+			name: "AD without an active_directory block",
+			input: []interface{}{
+				map[string]interface{}{
+					"directory_type":                 string(storageaccounts.DirectoryServiceOptionsAD),
+					"active_directory":               []interface{}{},
+					"default_share_level_permission": string(storageaccounts.DefaultSharePermissionNone),
+				},
+			},
+			error: true,
+		},
+		{
+			name: "AD without a storage_sid",
+			input: []interface{}{
+				map[string]interface{}{
+					"directory_type":                 string(storageaccounts.DirectoryServiceOptionsAD),
+					"active_directory":               activeDirectory(""),
+					"default_share_level_permission": string(storageaccounts.DefaultSharePermissionNone),
+				},
+			},
+			error: true,
+		},
+		{
+			name: "AD",
+			input: []interface{}{
+				map[string]interface{}{
+					"directory_type":                 string(storageaccounts.DirectoryServiceOptionsAD),
+					"active_directory":               activeDirectory("S-1-5-21-2400535526-2334094090-2402026252-0012"),
+					"default_share_level_permission": string(storageaccounts.DefaultSharePermissionNone),
+				},
+			},
+			expected: &storageaccounts.AzureFilesIdentityBasedAuthentication{
+				DirectoryServiceOptions: storageaccounts.DirectoryServiceOptionsAD,
+				ActiveDirectoryProperties: &storageaccounts.ActiveDirectoryProperties{
+					AzureStorageSid:   pointer.To("S-1-5-21-2400535526-2334094090-2402026252-0012"),
+					DomainGuid:        "13a20c9a-d491-47e6-8a39-299e7a32ea27",
+					DomainName:        "adtest.com",
+					DomainSid:         pointer.To("S-1-5-21-2400535526-2334094090-2402026252"),
+					ForestName:        pointer.To("adtest.com"),
+					NetBiosDomainName: pointer.To("adtest.com"),
+				},
+				DefaultSharePermission: pointer.To(storageaccounts.DefaultSharePermissionNone),
+			},
+		},
+	}
+
+	for _, v := range testData {
+		t.Logf("[DEBUG] Testing %q..", v.name)
+
+		actual, err := expandAccountAzureFilesAuthentication(v.input)
+		if err != nil {
+			if v.error {
+				continue
+			}
+
+			t.Fatalf("unexpected error: %+v", err)
+		}
+		if v.error {
+			t.Fatalf("expected an error but didn't get one")
+		}
+
+		if !reflect.DeepEqual(actual, v.expected) {
+			t.Fatalf("expected %+v but got %+v", v.expected, actual)
+		}
+	}
+}
@@ -4,9 +4,12 @@
 package storage
 
 import (
+	"fmt"
+	"sort"
 	"strings"
 
 	"github.com/hashicorp/go-azure-helpers/lang/pointer"
+	"github.com/hashicorp/go-azure-helpers/resourcemanager/commonids"
 	"github.com/hashicorp/go-azure-sdk/resource-manager/storage/2023-01-01/storageaccounts"
 	"github.com/hashicorp/terraform-provider-azurerm/internal/tf/pluginsdk"
 )
@@ -24,8 +27,15 @@ func expandAccountNetworkRules(input []interface{}, tenantId string) *storageacc
 	}
 
 	item := input[0].(map[string]interface{})
+
+	// when `bypass` isn't specified the API defaults to `AzureServices`, so send this explicitly to keep the plan stable
+	bypass := expandAccountNetworkRuleBypass(item["bypass"].(*pluginsdk.Set).List())
+	if bypass == nil {
+		bypass = pointer.To(storageaccounts.BypassAzureServices)
+	}
+
 	return &storageaccounts.NetworkRuleSet{
-		Bypass:              expandAccountNetworkRuleBypass(item["bypass"].(*pluginsdk.Set).List()),
+		Bypass:              bypass,
 		DefaultAction:       storageaccounts.DefaultAction(item["default_action"].(string)),
 		IPRules:             expandAccountNetworkRuleIPRules(item["ip_rules"].(*pluginsdk.Set).List()),
 		ResourceAccessRules: expandAccountNetworkRulePrivateLinkAccess(item["private_link_access"].([]interface{}), tenantId),
@@ -60,6 +70,100 @@ func flattenAccountNetworkRules(input *storageaccounts.NetworkRuleSet) []interfa
 	return output
 }
 
+// mergeAccountNetworkRules returns the desired Network Rules, additionally retaining any existing IP, Virtual Network and
+// Resource Access rules which aren't managed by this resource (that is, which weren't present in the previous configuration).
+func mergeAccountNetworkRules(existing, previous, desired *storageaccounts.NetworkRuleSet) *storageaccounts.NetworkRuleSet {
+	if existing == nil || desired == nil {
+		return desired
+	}
+
+	output := *desired
+	if previous == nil {
+		previous = &storageaccounts.NetworkRuleSet{}
+	}
+
+	output.IPRules = mergeAccountNetworkRuleItems(existing.IPRules, previous.IPRules, desired.IPRules, accountNetworkRuleIPRuleKey)
+	output.VirtualNetworkRules = mergeAccountNetworkRuleItems(existing.VirtualNetworkRules, previous.VirtualNetworkRules, desired.VirtualNetworkRules, accountNetworkRuleVirtualNetworkRuleKey)
+	output.ResourceAccessRules = mergeAccountNetworkRuleItems(existing.ResourceAccessRules, previous.ResourceAccessRules, desired.ResourceAccessRules, accountNetworkRuleResourceAccessRuleKey)
+
+	return &output
+}
+
+// managedAccountNetworkRules returns the Network Rules, limited to the IP, Virtual Network and Resource Access rules which
+// are managed by this resource - so that rules managed elsewhere don't show as a diff.
+func managedAccountNetworkRules(input, managed *storageaccounts.NetworkRuleSet) *storageaccounts.NetworkRuleSet {
+	if input == nil {
+		return nil
+	}
+
+	output := *input
+	if managed == nil {
+		managed = &storageaccounts.NetworkRuleSet{}
+	}
+
+	output.IPRules = filterAccountNetworkRuleItems(input.IPRules, managed.IPRules, accountNetworkRuleIPRuleKey)
+	output.VirtualNetworkRules = filterAccountNetworkRuleItems(input.VirtualNetworkRules, managed.VirtualNetworkRules, accountNetworkRuleVirtualNetworkRuleKey)
+	output.ResourceAccessRules = filterAccountNetworkRuleItems(input.ResourceAccessRules, managed.ResourceAccessRules, accountNetworkRuleResourceAccessRuleKey)
+
+	return &output
+}
+
+func mergeAccountNetworkRuleItems[T any](existing, previous, desired *[]T, key func(T) string) *[]T {
+	output := make([]T, 0)
+	seen := make(map[string]struct{})
+	for _, item := range pointer.From(desired) {
+		output = append(output, item)
+		seen[key(item)] = struct{}{}
+	}
+
+	previouslyManaged := make(map[string]struct{})
+	for _, item := range pointer.From(previous) {
+		previouslyManaged[key(item)] = struct{}{}
+	}
+
+	for _, item := range pointer.From(existing) {
+		k := key(item)
+		if _, ok := seen[k]; ok {
+			continue
+		}
+		if _, ok := previouslyManaged[k]; ok {
+			continue
+		}
+		output = append(output, item)
+		seen[k] = struct{}{}
+	}
+
+	return &output
+}
+
+func filterAccountNetworkRuleItems[T any](input, managed *[]T, key func(T) string) *[]T {
+	managedKeys := make(map[string]struct{})
+	for _, item := range pointer.From(managed) {
+		managedKeys[key(item)] = struct{}{}
+	}
+
+	output := make([]T, 0)
+	for _, item := range pointer.From(input) {
+		if _, ok := managedKeys[key(item)]; ok {
+			output = append(output, item)
+		}
+	}
+
+	return &output
+}
+
+func accountNetworkRuleIPRuleKey(input storageaccounts.IPRule) string {
+	return strings.ToLower(input.Value)
+}
+
+func accountNetworkRuleVirtualNetworkRuleKey(input storageaccounts.VirtualNetworkRule) string {
+	return strings.ToLower(input.Id)
+}
+
+func accountNetworkRuleResourceAccessRuleKey(input storageaccounts.ResourceAccessRule) string {
+	return strings.ToLower(pointer.From(input.ResourceId))
+}
+
 func expandAccountNetworkRuleBypass(input []interface{}) *storageaccounts.Bypass {
 	if len(input) == 0 {
 		return nil
@@ -95,6 +199,10 @@ func flattenAccountNetworkRuleBypass(input *storageaccounts.Bypass) []interface{
 		}
 	}
 
+	sort.Slice(output, func(i, j int) bool {
+		return output[i].(string) < output[j].(string)
+	})
+
 	return output
 }
 
@@ -121,6 +229,50 @@ func flattenAccountNetworkRuleIPRules(input *[]storageaccounts.IPRule) []interfa
 	return output
 }
 
+// accountNetworkRulesVirtualNetworkNames returns the (unique, sorted) names of the Virtual Networks containing the Subnets
+// referenced by the Network Rules, so that these can be locked - since the networking API's only allow a single change
+// to be made to a network layout at once.
+func accountNetworkRulesVirtualNetworkNames(input ...*storageaccounts.NetworkRuleSet) ([]string, error) {
+	names := make(map[string]struct{})
+	for _, rules := range input {
+		if rules == nil || rules.VirtualNetworkRules == nil {
+			continue
+		}
+
+		for _, v := range *rules.VirtualNetworkRules {
+			subnetId, err := commonids.ParseSubnetIDInsensitively(v.Id)
+			if err != nil {
+				return nil, err
+			}
+			names[subnetId.VirtualNetworkName] = struct{}{}
+		}
+	}
+
+	output := make([]string, 0)
+	for name := range names {
+		output = append(output, name)
+	}
+	sort.Strings(output)
+
+	return output, nil
+}
+
+// accountNetworkRulesDefaultActionChangeWarning returns a warning when the `default_action` of the Network Rules is
+// changed on a Storage Account with Private Endpoint Connections, since access can briefly be interrupted whilst the
+// change is applied - or an empty string when there's nothing to warn about.
+func accountNetworkRulesDefaultActionChangeWarning(existing, desired *storageaccounts.NetworkRuleSet, privateEndpointConnections *[]storageaccounts.PrivateEndpointConnection) string {
+	if existing == nil || desired == nil || existing.DefaultAction == desired.DefaultAction {
+		return ""
+	}
+
+	connections := len(pointer.From(privateEndpointConnections))
+	if connections == 0 {
+		return ""
+	}
+
+	return fmt.Sprintf("changing `network_rules.0.default_action` from %q to %q on a Storage Account with %d Private Endpoint Connection(s) can briefly interrupt access to the Storage Account whilst the change is applied", string(existing.DefaultAction), string(desired.DefaultAction), connections)
+}
+
 func expandAccountNetworkRuleVirtualNetworkRules(input []interface{}) *[]storageaccounts.VirtualNetworkRule {
 	output := make([]storageaccounts.VirtualNetworkRule, 0)
 
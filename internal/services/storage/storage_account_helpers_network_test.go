@@ -0,0 +1,401 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package storage
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/hashicorp/go-azure-helpers/lang/pointer"
+	"github.com/hashicorp/go-azure-sdk/resource-manager/network/2023-11-01/subnets"
+	"github.com/hashicorp/go-azure-sdk/resource-manager/storage/2023-01-01/storageaccounts"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/tf/pluginsdk"
+)
+
+func TestExpandAccountNetworkRulesBypass(t *testing.T) {
+	testData := []struct {
+		name     string
+		bypass   []interface{}
+		expected storageaccounts.Bypass
+	}{
+		{
+			name:     "omitted",
+			bypass:   []interface{}{},
+			expected: storageaccounts.BypassAzureServices,
+		},
+		{
+			name:     "logging only",
+			bypass:   []interface{}{"Logging"},
+			expected: storageaccounts.BypassLogging,
+		},
+		{
+			name:     "none",
+			bypass:   []interface{}{"None"},
+			expected: storageaccounts.BypassNone,
+		},
+	}
+
+	for _, v := range testData {
+		t.Logf("[DEBUG] Testing %q", v.name)
+
+		input := []interface{}{
+			map[string]interface{}{
+				"bypass":                     pluginsdk.NewSet(pluginsdk.HashString, v.bypass),
+				"default_action":             "Deny",
+				"ip_rules":                   pluginsdk.NewSet(pluginsdk.HashString, []interface{}{}),
+				"private_link_access":        []interface{}{},
+				"virtual_network_subnet_ids": pluginsdk.NewSet(pluginsdk.HashString, []interface{}{}),
+			},
+		}
+
+		actual := expandAccountNetworkRules(input, "")
+		if actual.Bypass == nil || *actual.Bypass != v.expected {
+			t.Fatalf("Expected %q but got %q", v.expected, pointer.From(actual.Bypass))
+		}
+	}
+}
+
+func TestFlattenAccountNetworkRuleBypass(t *testing.T) {
+	testData := []struct {
+		input    *storageaccounts.Bypass
+		expected []interface{}
+	}{
+		{
+			input:    nil,
+			expected: []interface{}{},
+		},
+		{
+			input:    pointer.To(storageaccounts.BypassAzureServices),
+			expected: []interface{}{"AzureServices"},
+		},
+		{
+			input:    pointer.To(storageaccounts.Bypass("Metrics, logging, AzureServices")),
+			expected: []interface{}{"AzureServices", "Logging", "Metrics"},
+		},
+		{
+			input:    pointer.To(storageaccounts.Bypass("Logging, Metrics")),
+			expected: []interface{}{"Logging", "Metrics"},
+		},
+	}
+
+	for _, v := range testData {
+		t.Logf("[DEBUG] Testing %q", pointer.From(v.input))
+
+		actual := flattenAccountNetworkRuleBypass(v.input)
+		if !reflect.DeepEqual(actual, v.expected) {
+			t.Fatalf("Expected %+v but got %+v", v.expected, actual)
+		}
+	}
+}
+
+func TestMergeAccountNetworkRules(t *testing.T) {
+	existing := &storageaccounts.NetworkRuleSet{
+		DefaultAction: storageaccounts.DefaultActionDeny,
+		IPRules: &[]storageaccounts.IPRule{
+			{Value: "10.0.0.1"},
+			{Value: "10.0.0.2"},
+			{Value: "10.0.0.3"},
+		},
+		VirtualNetworkRules: &[]storageaccounts.VirtualNetworkRule{
+			{Id: "/subscriptions/00000000-0000-0000-0000-000000000000/resourceGroups/rg/providers/Microsoft.Network/virtualNetworks/vnet/subnets/external"},
+		},
+	}
+	previous := &storageaccounts.NetworkRuleSet{
+		IPRules: &[]storageaccounts.IPRule{
+			{Value: "10.0.0.1"},
+			{Value: "10.0.0.2"},
+		},
+	}
+	desired := &storageaccounts.NetworkRuleSet{
+		DefaultAction: storageaccounts.DefaultActionDeny,
+		IPRules: &[]storageaccounts.IPRule{
+			{Value: "10.0.0.1"},
+			{Value: "10.0.0.4"},
+		},
+		VirtualNetworkRules: &[]storageaccounts.VirtualNetworkRule{},
+		ResourceAccessRules: &[]storageaccounts.ResourceAccessRule{},
+	}
+
+	actual := mergeAccountNetworkRules(existing, previous, desired)
+
+	// 10.0.0.2 was previously managed and has been removed, 10.0.0.3 is managed elsewhere so is retained
+	expectedIPRules := []storageaccounts.IPRule{
+		{Value: "10.0.0.1"},
+		{Value: "10.0.0.4"},
+		{Value: "10.0.0.3"},
+	}
+	if !reflect.DeepEqual(pointer.From(actual.IPRules), expectedIPRules) {
+		t.Fatalf("Expected IP Rules %+v but got %+v", expectedIPRules, pointer.From(actual.IPRules))
+	}
+	if !reflect.DeepEqual(pointer.From(actual.VirtualNetworkRules), pointer.From(existing.VirtualNetworkRules)) {
+		t.Fatalf("Expected Virtual Network Rules %+v but got %+v", pointer.From(existing.VirtualNetworkRules), pointer.From(actual.VirtualNetworkRules))
+	}
+	if len(pointer.From(actual.ResourceAccessRules)) != 0 {
+		t.Fatalf("Expected no Resource Access Rules but got %+v", pointer.From(actual.ResourceAccessRules))
+	}
+
+	if actual := mergeAccountNetworkRules(nil, previous, desired); actual != desired {
+		t.Fatalf("Expected the desired Network Rules to be returned when there are no existing rules")
+	}
+}
+
+func TestManagedAccountNetworkRules(t *testing.T) {
+	input := &storageaccounts.NetworkRuleSet{
+		DefaultAction: storageaccounts.DefaultActionDeny,
+		IPRules: &[]storageaccounts.IPRule{
+			{Value: "10.0.0.1"},
+			{Value: "10.0.0.3"},
+		},
+		ResourceAccessRules: &[]storageaccounts.ResourceAccessRule{
+			{ResourceId: pointer.To("/subscriptions/00000000-0000-0000-0000-000000000000/resourceGroups/rg/providers/Microsoft.Synapse/workspaces/ws")},
+		},
+	}
+	managed := &storageaccounts.NetworkRuleSet{
+		IPRules: &[]storageaccounts.IPRule{
+			{Value: "10.0.0.1"},
+		},
+		ResourceAccessRules: &[]storageaccounts.ResourceAccessRule{
+			{ResourceId: pointer.To("/subscriptions/00000000-0000-0000-0000-000000000000/resourceGroups/RG/providers/Microsoft.Synapse/workspaces/ws")},
+		},
+	}
+
+	actual := managedAccountNetworkRules(input, managed)
+
+	expectedIPRules := []storageaccounts.IPRule{
+		{Value: "10.0.0.1"},
+	}
+	if !reflect.DeepEqual(pointer.From(actual.IPRules), expectedIPRules) {
+		t.Fatalf("Expected IP Rules %+v but got %+v", expectedIPRules, pointer.From(actual.IPRules))
+	}
+	if len(pointer.From(actual.ResourceAccessRules)) != 1 {
+		t.Fatalf("Expected 1 Resource Access Rule but got %+v", pointer.From(actual.ResourceAccessRules))
+	}
+	if actual.DefaultAction != storageaccounts.DefaultActionDeny {
+		t.Fatalf("Expected the Default Action to be retained but got %q", actual.DefaultAction)
+	}
+}
+
+func TestSubnetHasStorageServiceEndpoint(t *testing.T) {
+	testData := []struct {
+		name     string
+		input    *subnets.Subnet
+		expected bool
+	}{
+		{
+			name:     "nil subnet",
+			input:    nil,
+			expected: false,
+		},
+		{
+			name: "no service endpoints",
+			input: &subnets.Subnet{
+				Properties: &subnets.SubnetPropertiesFormat{},
+			},
+			expected: false,
+		},
+		{
+			name: "other service endpoint",
+			input: &subnets.Subnet{
+				Properties: &subnets.SubnetPropertiesFormat{
+					ServiceEndpoints: &[]subnets.ServiceEndpointPropertiesFormat{
+						{Service: pointer.To("Microsoft.Sql")},
+					},
+				},
+			},
+			expected: false,
+		},
+		{
+			name: "storage service endpoint",
+			input: &subnets.Subnet{
+				Properties: &subnets.SubnetPropertiesFormat{
+					ServiceEndpoints: &[]subnets.ServiceEndpointPropertiesFormat{
+						{Service: pointer.To("Microsoft.Sql")},
+						{Service: pointer.To("Microsoft.Storage")},
+					},
+				},
+			},
+			expected: true,
+		},
+		{
+			name: "global storage service endpoint",
+			input: &subnets.Subnet{
+				Properties: &subnets.SubnetPropertiesFormat{
+					ServiceEndpoints: &[]subnets.ServiceEndpointPropertiesFormat{
+						{Service: pointer.To("Microsoft.Storage.Global")},
+					},
+				},
+			},
+			expected: true,
+		},
+	}
+
+	for _, v := range testData {
+		t.Logf("[DEBUG] Testing %q..", v.name)
+
+		if actual := subnetHasStorageServiceEndpoint(v.input); actual != v.expected {
+			t.Fatalf("expected %t but got %t", v.expected, actual)
+		}
+	}
+}
+
+func TestValidateAccountReplicationTypeForEdgeZone(t *testing.T) {
+	testData := []struct {
+		edgeZone        string
+		replicationType string
+		shouldError     bool
+	}{
+		{
+			edgeZone:        "",
+			replicationType: "GRS",
+			shouldError:     false,
+		},
+		{
+			edgeZone:        "microsoftlosangeles1",
+			replicationType: "LRS",
+			shouldError:     false,
+		},
+		{
+			edgeZone:        "microsoftlosangeles1",
+			replicationType: "ZRS",
+			shouldError:     false,
+		},
+		{
+			edgeZone:        "microsoftlosangeles1",
+			replicationType: "GRS",
+			shouldError:     true,
+		},
+		{
+			edgeZone:        "microsoftlosangeles1",
+			replicationType: "RAGZRS",
+			shouldError:     true,
+		},
+	}
+
+	for _, v := range testData {
+		t.Logf("[DEBUG] Testing %q in %q..", v.replicationType, v.edgeZone)
+
+		err := validateAccountReplicationTypeForEdgeZone(v.edgeZone, v.replicationType)
+		if v.shouldError && err == nil {
+			t.Fatalf("expected an error but didn't get one")
+		}
+		if !v.shouldError && err != nil {
+			t.Fatalf("expected no error but got: %+v", err)
+		}
+	}
+}
+
+func TestAccountNetworkRulesVirtualNetworkNames(t *testing.T) {
+	subnetId := func(virtualNetworkName, subnetName string) string {
+		return "/subscriptions/00000000-0000-0000-0000-000000000000/resourceGroups/example/providers/Microsoft.Network/virtualNetworks/" + virtualNetworkName + "/subnets/" + subnetName
+	}
+
+	testData := []struct {
+		name     string
+		input    []*storageaccounts.NetworkRuleSet
+		expected []string
+		error    bool
+	}{
+		{
+			name:     "nil",
+			input:    []*storageaccounts.NetworkRuleSet{nil},
+			expected: []string{},
+		},
+		{
+			name: "duplicates across subnets and rule sets",
+			input: []*storageaccounts.NetworkRuleSet{
+				{
+					VirtualNetworkRules: &[]storageaccounts.VirtualNetworkRule{
+						{Id: subnetId("network2", "subnet1")},
+						{Id: subnetId("network1", "subnet1")},
+						{Id: subnetId("network1", "subnet2")},
+					},
+				},
+				{
+					VirtualNetworkRules: &[]storageaccounts.VirtualNetworkRule{
+						{Id: subnetId("network1", "subnet1")},
+						{Id: subnetId("network3", "subnet1")},
+					},
+				},
+			},
+			expected: []string{"network1", "network2", "network3"},
+		},
+		{
+			name: "invalid subnet id",
+			input: []*storageaccounts.NetworkRuleSet{
+				{
+					VirtualNetworkRules: &[]storageaccounts.VirtualNetworkRule{
+						{Id: "not-a-subnet-id"},
+					},
+				},
+			},
+			error: true,
+		},
+	}
+
+	for _, v := range testData {
+		t.Logf("[DEBUG] Testing %q..", v.name)
+
+		actual, err := accountNetworkRulesVirtualNetworkNames(v.input...)
+		if err != nil {
+			if v.error {
+				continue
+			}
+			t.Fatalf("unexpected error: %+v", err)
+		}
+		if v.error {
+			t.Fatalf("expected an error but didn't get one")
+		}
+
+		if !reflect.DeepEqual(actual, v.expected) {
+			t.Fatalf("expected %+v but got %+v", v.expected, actual)
+		}
+	}
+}
+
+func TestAccountNetworkRulesDefaultActionChangeWarning(t *testing.T) {
+	allow := &storageaccounts.NetworkRuleSet{DefaultAction: storageaccounts.DefaultActionAllow}
+	deny := &storageaccounts.NetworkRuleSet{DefaultAction: storageaccounts.DefaultActionDeny}
+	connections := &[]storageaccounts.PrivateEndpointConnection{{}}
+
+	testData := []struct {
+		name        string
+		existing    *storageaccounts.NetworkRuleSet
+		desired     *storageaccounts.NetworkRuleSet
+		connections *[]storageaccounts.PrivateEndpointConnection
+		warning     bool
+	}{
+		{
+			name:        "unchanged",
+			existing:    deny,
+			desired:     deny,
+			connections: connections,
+		},
+		{
+			name:     "changed without private endpoint connections",
+			existing: allow,
+			desired:  deny,
+		},
+		{
+			name:        "changed with private endpoint connections",
+			existing:    allow,
+			desired:     deny,
+			connections: connections,
+			warning:     true,
+		},
+		{
+			name:        "no existing rules",
+			desired:     deny,
+			connections: connections,
+		},
+	}
+
+	for _, v := range testData {
+		t.Logf("[DEBUG] Testing %q..", v.name)
+
+		actual := accountNetworkRulesDefaultActionChangeWarning(v.existing, v.desired, v.connections)
+		if v.warning != (actual != "") {
+			t.Fatalf("expected a warning to be %t but got %q", v.warning, actual)
+		}
+	}
+}
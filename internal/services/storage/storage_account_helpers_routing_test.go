@@ -0,0 +1,97 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package storage
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/hashicorp/go-azure-helpers/lang/pointer"
+	"github.com/hashicorp/go-azure-sdk/resource-manager/storage/2023-01-01/storageaccounts"
+)
+
+func TestFlattenAccountRoutingPreference(t *testing.T) {
+	defaultRouting := []interface{}{
+		map[string]interface{}{
+			"choice":                      string(storageaccounts.RoutingChoiceMicrosoftRouting),
+			"publish_internet_endpoints":  false,
+			"publish_microsoft_endpoints": false,
+		},
+	}
+
+	testData := []struct {
+		name     string
+		input    *storageaccounts.RoutingPreference
+		existing []interface{}
+		expected []interface{}
+	}{
+		{
+			name:     "no routing preference",
+			expected: []interface{}{},
+		},
+		{
+			name: "defaults are omitted",
+			input: &storageaccounts.RoutingPreference{
+				RoutingChoice:             pointer.To(storageaccounts.RoutingChoiceMicrosoftRouting),
+				PublishInternetEndpoints:  pointer.To(false),
+				PublishMicrosoftEndpoints: pointer.To(false),
+			},
+			expected: []interface{}{},
+		},
+		{
+			name:     "empty routing preference is treated as the defaults",
+			input:    &storageaccounts.RoutingPreference{},
+			expected: []interface{}{},
+		},
+		{
+			name: "defaults are kept when already in the state",
+			input: &storageaccounts.RoutingPreference{
+				RoutingChoice:             pointer.To(storageaccounts.RoutingChoiceMicrosoftRouting),
+				PublishInternetEndpoints:  pointer.To(false),
+				PublishMicrosoftEndpoints: pointer.To(false),
+			},
+			existing: defaultRouting,
+			expected: defaultRouting,
+		},
+		{
+			name: "internet routing",
+			input: &storageaccounts.RoutingPreference{
+				RoutingChoice:             pointer.To(storageaccounts.RoutingChoiceInternetRouting),
+				PublishInternetEndpoints:  pointer.To(false),
+				PublishMicrosoftEndpoints: pointer.To(false),
+			},
+			expected: []interface{}{
+				map[string]interface{}{
+					"choice":                      string(storageaccounts.RoutingChoiceInternetRouting),
+					"publish_internet_endpoints":  false,
+					"publish_microsoft_endpoints": false,
+				},
+			},
+		},
+		{
+			name: "microsoft routing publishing endpoints",
+			input: &storageaccounts.RoutingPreference{
+				RoutingChoice:             pointer.To(storageaccounts.RoutingChoiceMicrosoftRouting),
+				PublishInternetEndpoints:  pointer.To(true),
+				PublishMicrosoftEndpoints: pointer.To(false),
+			},
+			expected: []interface{}{
+				map[string]interface{}{
+					"choice":                      string(storageaccounts.RoutingChoiceMicrosoftRouting),
+					"publish_internet_endpoints":  true,
+					"publish_microsoft_endpoints": false,
+				},
+			},
+		},
+	}
+
+	for _, v := range testData {
+		t.Logf("[DEBUG] Testing %q..", v.name)
+
+		actual := flattenAccountRoutingPreference(v.input, v.existing)
+		if !reflect.DeepEqual(actual, v.expected) {
+			t.Fatalf("expected %+v but got %+v", v.expected, actual)
+		}
+	}
+}
@@ -0,0 +1,111 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package storage
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/go-azure-helpers/lang/pointer"
+	"github.com/hashicorp/go-azure-helpers/resourcemanager/commonids"
+	"github.com/hashicorp/go-azure-sdk/resource-manager/resources/2023-07-01/resourcegroups"
+)
+
+// retrieveResourceGroupTags returns the Tags assigned to the Resource Group, for use when the
+// `inherit_resource_group_tags` feature is enabled.
+func retrieveResourceGroupTags(ctx context.Context, client *resourcegroups.ResourceGroupsClient, id commonids.ResourceGroupId) (map[string]string, error) {
+	resp, err := client.Get(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("retrieving %s: %+v", id, err)
+	}
+
+	if model := resp.Model; model != nil && model.Tags != nil {
+		return *model.Tags, nil
+	}
+
+	return map[string]string{}, nil
+}
+
+// mergeResourceGroupTags returns the Tags assigned to the Resource Group combined with the configured Tags - where a
+// Tag is both configured and assigned to the Resource Group the configured value takes precedence.
+func mergeResourceGroupTags(resourceGroupTags map[string]string, configured *map[string]string) *map[string]string {
+	output := make(map[string]string)
+	for k, v := range resourceGroupTags {
+		output[k] = v
+	}
+	for k, v := range pointer.From(configured) {
+		output[k] = v
+	}
+
+	return &output
+}
+
+// removeInheritedResourceGroupTags removes the Tags which were inherited from the Resource Group from the Tags assigned
+// to the Storage Account, so that these don't show as a diff against the configured Tags. Tags which are also present
+// in the existing state (and so were configured) are kept, as are Tags whose value differs from the Resource Group.
+func removeInheritedResourceGroupTags(resourceGroupTags map[string]string, input *map[string]string, existing map[string]interface{}) *map[string]string {
+	if input == nil {
+		return nil
+	}
+
+	output := make(map[string]string)
+	for k, v := range *input {
+		if _, configured := existing[k]; !configured {
+			if inherited, ok := resourceGroupTags[k]; ok && inherited == v {
+				continue
+			}
+		}
+		output[k] = v
+	}
+
+	return &output
+}
+
+// isIgnoredTag returns whether the Tag key starts with one of the prefixes configured in the `ignored_tag_prefixes`
+// feature, which Azure adds to Storage Accounts used by other services (for example `hidden-link:`).
+func isIgnoredTag(prefixes []string, key string) bool {
+	for _, prefix := range prefixes {
+		if prefix != "" && strings.HasPrefix(strings.ToLower(key), strings.ToLower(prefix)) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// removeIgnoredTags removes the Tags matching the `ignored_tag_prefixes` feature from the Tags assigned to the Storage
+// Account, so that Tags added outside of Terraform don't show as a diff. Tags which are also present in the existing
+// state (and so were configured) are kept.
+func removeIgnoredTags(prefixes []string, input *map[string]string, existing map[string]interface{}) *map[string]string {
+	if input == nil {
+		return nil
+	}
+
+	output := make(map[string]string)
+	for k, v := range *input {
+		if _, configured := existing[k]; !configured && isIgnoredTag(prefixes, k) {
+			continue
+		}
+		output[k] = v
+	}
+
+	return &output
+}
+
+// mergeIgnoredTags returns the configured Tags combined with the Tags matching the `ignored_tag_prefixes` feature which
+// are currently assigned to the Storage Account, so that these aren't removed when the Tags are updated.
+func mergeIgnoredTags(prefixes []string, existing *map[string]string, configured *map[string]string) *map[string]string {
+	output := make(map[string]string)
+	for k, v := range pointer.From(existing) {
+		if isIgnoredTag(prefixes, k) {
+			output[k] = v
+		}
+	}
+	for k, v := range pointer.From(configured) {
+		output[k] = v
+	}
+
+	return &output
+}
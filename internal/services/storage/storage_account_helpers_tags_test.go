@@ -0,0 +1,178 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package storage
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/hashicorp/go-azure-helpers/lang/pointer"
+)
+
+func TestMergeResourceGroupTags(t *testing.T) {
+	testData := []struct {
+		name              string
+		resourceGroupTags map[string]string
+		configured        *map[string]string
+		expected          map[string]string
+	}{
+		{
+			name:              "nothing to inherit",
+			resourceGroupTags: map[string]string{},
+			configured:        pointer.To(map[string]string{"env": "test"}),
+			expected:          map[string]string{"env": "test"},
+		},
+		{
+			name:              "nothing configured",
+			resourceGroupTags: map[string]string{"cost-centre": "1234"},
+			configured:        nil,
+			expected:          map[string]string{"cost-centre": "1234"},
+		},
+		{
+			name:              "configured tags take precedence",
+			resourceGroupTags: map[string]string{"cost-centre": "1234", "env": "prod"},
+			configured:        pointer.To(map[string]string{"env": "test"}),
+			expected:          map[string]string{"cost-centre": "1234", "env": "test"},
+		},
+	}
+
+	for _, v := range testData {
+		t.Logf("[DEBUG] Testing %q..", v.name)
+
+		actual := mergeResourceGroupTags(v.resourceGroupTags, v.configured)
+		if !reflect.DeepEqual(*actual, v.expected) {
+			t.Fatalf("expected %+v but got %+v", v.expected, *actual)
+		}
+	}
+}
+
+func TestRemoveInheritedResourceGroupTags(t *testing.T) {
+	testData := []struct {
+		name              string
+		resourceGroupTags map[string]string
+		input             map[string]string
+		existing          map[string]interface{}
+		expected          map[string]string
+	}{
+		{
+			name:              "inherited tag is removed",
+			resourceGroupTags: map[string]string{"cost-centre": "1234"},
+			input:             map[string]string{"cost-centre": "1234", "env": "test"},
+			existing:          map[string]interface{}{"env": "test"},
+			expected:          map[string]string{"env": "test"},
+		},
+		{
+			name:              "configured tag matching the resource group is kept",
+			resourceGroupTags: map[string]string{"cost-centre": "1234"},
+			input:             map[string]string{"cost-centre": "1234"},
+			existing:          map[string]interface{}{"cost-centre": "1234"},
+			expected:          map[string]string{"cost-centre": "1234"},
+		},
+		{
+			name:              "tag differing from the resource group is kept",
+			resourceGroupTags: map[string]string{"cost-centre": "1234"},
+			input:             map[string]string{"cost-centre": "5678"},
+			existing:          map[string]interface{}{},
+			expected:          map[string]string{"cost-centre": "5678"},
+		},
+	}
+
+	for _, v := range testData {
+		t.Logf("[DEBUG] Testing %q..", v.name)
+
+		actual := removeInheritedResourceGroupTags(v.resourceGroupTags, pointer.To(v.input), v.existing)
+		if !reflect.DeepEqual(*actual, v.expected) {
+			t.Fatalf("expected %+v but got %+v", v.expected, *actual)
+		}
+	}
+}
+
+func TestRemoveIgnoredTags(t *testing.T) {
+	prefixes := []string{"hidden-link:", "hidden-related:"}
+
+	testData := []struct {
+		name     string
+		prefixes []string
+		input    map[string]string
+		existing map[string]interface{}
+		expected map[string]string
+	}{
+		{
+			name:     "ignored tag is removed",
+			prefixes: prefixes,
+			input:    map[string]string{"env": "test", "hidden-link:/subscriptions/123": "Resource"},
+			existing: map[string]interface{}{"env": "test"},
+			expected: map[string]string{"env": "test"},
+		},
+		{
+			name:     "prefixes are matched case-insensitively",
+			prefixes: prefixes,
+			input:    map[string]string{"Hidden-Related:/subscriptions/123": "empty"},
+			existing: map[string]interface{}{},
+			expected: map[string]string{},
+		},
+		{
+			name:     "configured ignored tag is kept",
+			prefixes: prefixes,
+			input:    map[string]string{"hidden-link:/subscriptions/123": "Resource"},
+			existing: map[string]interface{}{"hidden-link:/subscriptions/123": "Resource"},
+			expected: map[string]string{"hidden-link:/subscriptions/123": "Resource"},
+		},
+		{
+			name:     "no prefixes",
+			prefixes: []string{},
+			input:    map[string]string{"hidden-link:/subscriptions/123": "Resource"},
+			existing: map[string]interface{}{},
+			expected: map[string]string{"hidden-link:/subscriptions/123": "Resource"},
+		},
+	}
+
+	for _, v := range testData {
+		t.Logf("[DEBUG] Testing %q..", v.name)
+
+		actual := removeIgnoredTags(v.prefixes, pointer.To(v.input), v.existing)
+		if !reflect.DeepEqual(*actual, v.expected) {
+			t.Fatalf("expected %+v but got %+v", v.expected, *actual)
+		}
+	}
+}
+
+func TestMergeIgnoredTags(t *testing.T) {
+	prefixes := []string{"hidden-link:"}
+
+	testData := []struct {
+		name       string
+		existing   *map[string]string
+		configured *map[string]string
+		expected   map[string]string
+	}{
+		{
+			name:       "no existing tags",
+			existing:   nil,
+			configured: pointer.To(map[string]string{"env": "test"}),
+			expected:   map[string]string{"env": "test"},
+		},
+		{
+			name:       "ignored tags are kept and removed tags aren't",
+			existing:   pointer.To(map[string]string{"env": "prod", "hidden-link:/subscriptions/123": "Resource"}),
+			configured: pointer.To(map[string]string{"owner": "example"}),
+			expected:   map[string]string{"owner": "example", "hidden-link:/subscriptions/123": "Resource"},
+		},
+		{
+			name:       "configured tags take precedence",
+			existing:   pointer.To(map[string]string{"hidden-link:/subscriptions/123": "Resource"}),
+			configured: pointer.To(map[string]string{"hidden-link:/subscriptions/123": "Other"}),
+			expected:   map[string]string{"hidden-link:/subscriptions/123": "Other"},
+		},
+	}
+
+	for _, v := range testData {
+		t.Logf("[DEBUG] Testing %q..", v.name)
+
+		actual := mergeIgnoredTags(prefixes, v.existing, v.configured)
+		if !reflect.DeepEqual(*actual, v.expected) {
+			t.Fatalf("expected %+v but got %+v", v.expected, *actual)
+		}
+	}
+}
@@ -19,6 +19,7 @@ import (
 	"github.com/hashicorp/go-azure-helpers/resourcemanager/identity"
 	"github.com/hashicorp/go-azure-helpers/resourcemanager/location"
 	"github.com/hashicorp/go-azure-helpers/resourcemanager/tags"
+	"github.com/hashicorp/go-azure-sdk/resource-manager/network/2023-11-01/subnets"
 	"github.com/hashicorp/go-azure-sdk/resource-manager/storage/2023-01-01/blobservice"
 	"github.com/hashicorp/go-azure-sdk/resource-manager/storage/2023-01-01/fileservice"
 	"github.com/hashicorp/go-azure-sdk/resource-manager/storage/2023-01-01/storageaccounts"
@@ -34,6 +35,7 @@ import (
 	managedHsmParse "github.com/hashicorp/terraform-provider-azurerm/internal/services/managedhsm/parse"
 	managedHsmValidate "github.com/hashicorp/terraform-provider-azurerm/internal/services/managedhsm/validate"
 	"github.com/hashicorp/terraform-provider-azurerm/internal/services/network"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/services/storage/client"
 	"github.com/hashicorp/terraform-provider-azurerm/internal/services/storage/helpers"
 	"github.com/hashicorp/terraform-provider-azurerm/internal/services/storage/migration"
 	"github.com/hashicorp/terraform-provider-azurerm/internal/services/storage/validate"
@@ -45,23 +47,7 @@ import (
 	"github.com/tombuildsstuff/giovanni/storage/2023-11-03/queue/queues"
 )
 
-var (
-	storageAccountResourceName  = "azurerm_storage_account"
-	storageKindsSupportsSkuTier = map[storageaccounts.Kind]struct{}{
-		storageaccounts.KindBlobStorage: {},
-		storageaccounts.KindFileStorage: {},
-		storageaccounts.KindStorageVTwo: {},
-	}
-	storageKindsSupportHns = map[storageaccounts.Kind]struct{}{
-		storageaccounts.KindBlobStorage:      {},
-		storageaccounts.KindBlockBlobStorage: {},
-		storageaccounts.KindStorageVTwo:      {},
-	}
-	storageKindsSupportLargeFileShares = map[storageaccounts.Kind]struct{}{
-		storageaccounts.KindFileStorage: {},
-		storageaccounts.KindStorageVTwo: {},
-	}
-)
+var storageAccountResourceName = "azurerm_storage_account"
 
 func resourceStorageAccount() *pluginsdk.Resource {
 	resource := &pluginsdk.Resource{
@@ -70,12 +56,13 @@ func resourceStorageAccount() *pluginsdk.Resource {
 		Update: resourceStorageAccountUpdate,
 		Delete: resourceStorageAccountDelete,
 
-		SchemaVersion: 4,
+		SchemaVersion: 5,
 		StateUpgraders: pluginsdk.StateUpgrades(map[int]pluginsdk.StateUpgrade{
 			0: migration.AccountV0ToV1{},
 			1: migration.AccountV1ToV2{},
 			2: migration.AccountV2ToV3{},
 			3: migration.AccountV3ToV4{},
+			4: migration.AccountV4ToV5{},
 		}),
 
 		Importer: pluginsdk.ImporterValidatingResourceId(func(id string) error {
@@ -266,6 +253,12 @@ func resourceStorageAccount() *pluginsdk.Resource {
 							Required:     true,
 							ValidateFunc: commonids.ValidateUserAssignedIdentityID,
 						},
+
+						"federated_identity_client_id": {
+							Type:         pluginsdk.TypeString,
+							Optional:     true,
+							ValidateFunc: validation.IsUUID,
+						},
 					},
 				},
 			},
@@ -329,6 +322,11 @@ func resourceStorageAccount() *pluginsdk.Resource {
 				Default:  true,
 			},
 
+			"data_plane_access_on_read_enabled": {
+				Type:     pluginsdk.TypeBool,
+				Optional: true,
+			},
+
 			"shared_access_key_enabled": {
 				Type:     pluginsdk.TypeBool,
 				Optional: true,
@@ -403,6 +401,12 @@ func resourceStorageAccount() *pluginsdk.Resource {
 							ValidateFunc: validation.StringInSlice(storageaccounts.PossibleValuesForDefaultAction(), false),
 						},
 
+						"merge_existing_rules": {
+							Type:     pluginsdk.TypeBool,
+							Optional: true,
+							Default:  false,
+						},
+
 						"private_link_access": {
 							Type:     pluginsdk.TypeList,
 							Optional: true,
@@ -443,9 +447,16 @@ func resourceStorageAccount() *pluginsdk.Resource {
 						},
 
 						"change_feed_retention_in_days": {
-							Type:         pluginsdk.TypeInt,
-							Optional:     true,
-							ValidateFunc: validation.IntBetween(1, 146000),
+							Type:     pluginsdk.TypeInt,
+							Optional: true,
+							ValidateFunc: validation.Any(
+								validation.IntBetween(1, 146000),
+								validation.IntInSlice([]int{-1}),
+							),
+							// `-1` explicitly requests infinite retention, which the API represents by omitting the retention
+							DiffSuppressFunc: func(_, old, new string, _ *pluginsdk.ResourceData) bool {
+								return new == "-1" && (old == "" || old == "0")
+							},
 						},
 
 						"container_delete_retention_policy": {
@@ -460,6 +471,11 @@ func resourceStorageAccount() *pluginsdk.Resource {
 										Default:      7,
 										ValidateFunc: validation.IntBetween(1, 365),
 									},
+									"permanent_delete_enabled": {
+										Type:     pluginsdk.TypeBool,
+										Optional: true,
+										Default:  false,
+									},
 								},
 							},
 						},
@@ -485,11 +501,6 @@ func resourceStorageAccount() *pluginsdk.Resource {
 										Default:      7,
 										ValidateFunc: validation.IntBetween(1, 365),
 									},
-									"permanent_delete_enabled": {
-										Type:     pluginsdk.TypeBool,
-										Optional: true,
-										Default:  false,
-									},
 								},
 							},
 						},
@@ -541,8 +552,9 @@ func resourceStorageAccount() *pluginsdk.Resource {
 								Schema: map[string]*pluginsdk.Schema{
 									"version": {
 										Type:         pluginsdk.TypeString,
-										Required:     true,
-										ValidateFunc: validation.StringIsNotEmpty,
+										Optional:     true,
+										Default:      validate.StorageAnalyticsDefaultVersion,
+										ValidateFunc: validate.StorageAnalyticsMetricsVersion,
 									},
 									// TODO 4.0: Remove this property and determine whether to enable based on existence of the out side block.
 									"enabled": {
@@ -570,8 +582,9 @@ func resourceStorageAccount() *pluginsdk.Resource {
 								Schema: map[string]*pluginsdk.Schema{
 									"version": {
 										Type:         pluginsdk.TypeString,
-										Required:     true,
-										ValidateFunc: validation.StringIsNotEmpty,
+										Optional:     true,
+										Default:      validate.StorageAnalyticsDefaultVersion,
+										ValidateFunc: validate.StorageAnalyticsLoggingVersion,
 									},
 									"delete": {
 										Type:     pluginsdk.TypeBool,
@@ -602,8 +615,9 @@ func resourceStorageAccount() *pluginsdk.Resource {
 								Schema: map[string]*pluginsdk.Schema{
 									"version": {
 										Type:         pluginsdk.TypeString,
-										Required:     true,
-										ValidateFunc: validation.StringIsNotEmpty,
+										Optional:     true,
+										Default:      validate.StorageAnalyticsDefaultVersion,
+										ValidateFunc: validate.StorageAnalyticsMetricsVersion,
 									},
 									// TODO 4.0: Remove this property and determine whether to enable based on existence of the out side block.
 									"enabled": {
@@ -773,7 +787,6 @@ func resourceStorageAccount() *pluginsdk.Resource {
 			"queue_encryption_key_type": {
 				Type:         pluginsdk.TypeString,
 				Optional:     true,
-				ForceNew:     true,
 				ValidateFunc: validation.StringInSlice(storageaccounts.PossibleValuesForKeyType(), false),
 				Default:      string(storageaccounts.KeyTypeService),
 			},
@@ -781,7 +794,6 @@ func resourceStorageAccount() *pluginsdk.Resource {
 			"table_encryption_key_type": {
 				Type:         pluginsdk.TypeString,
 				Optional:     true,
-				ForceNew:     true,
 				ValidateFunc: validation.StringInSlice(storageaccounts.PossibleValuesForKeyType(), false),
 				Default:      string(storageaccounts.KeyTypeService),
 			},
@@ -811,7 +823,7 @@ func resourceStorageAccount() *pluginsdk.Resource {
 						"expiration_period": {
 							Type:         pluginsdk.TypeString,
 							Required:     true,
-							ValidateFunc: validation.StringIsNotEmpty,
+							ValidateFunc: validate.StorageAccountSasExpirationPeriod,
 						},
 					},
 				},
@@ -1171,6 +1183,19 @@ func resourceStorageAccount() *pluginsdk.Resource {
 				Computed: true,
 			},
 
+			"public_containers": {
+				Type:     pluginsdk.TypeList,
+				Computed: true,
+				Elem: &pluginsdk.Schema{
+					Type: pluginsdk.TypeString,
+				},
+			},
+
+			"has_immutability_policy": {
+				Type:     pluginsdk.TypeBool,
+				Computed: true,
+			},
+
 			"primary_access_key": {
 				Type:      pluginsdk.TypeString,
 				Sensitive: true,
@@ -1183,6 +1208,11 @@ func resourceStorageAccount() *pluginsdk.Resource {
 				Sensitive: true,
 			},
 
+			"key_last_rotated": {
+				Type:     pluginsdk.TypeString,
+				Computed: true,
+			},
+
 			"primary_connection_string": {
 				Type:      pluginsdk.TypeString,
 				Computed:  true,
@@ -1238,11 +1268,19 @@ func resourceStorageAccount() *pluginsdk.Resource {
 					}
 				}
 
-				if d.Get("access_tier") != "" {
-					accountKind := storageaccounts.Kind(d.Get("account_kind").(string))
-					if _, ok := storageKindsSupportsSkuTier[accountKind]; !ok {
-						keys := sortedKeysFromSlice(storageKindsSupportsSkuTier)
-						return fmt.Errorf("`access_tier` is only available for accounts where `kind` is set to one of: %+v", strings.Join(keys, " / "))
+				// the remaining kind/tier combinations are validated during the create/update, since these depend on values
+				// which may not be known until then
+				if d.NewValueKnown("account_kind") && d.NewValueKnown("account_tier") {
+					issues := validate.StorageAccountKindOptionsSupported(validate.StorageAccountKindOptions{
+						Kind:                            storageaccounts.Kind(d.Get("account_kind").(string)),
+						Tier:                            storageaccounts.SkuTier(d.Get("account_tier").(string)),
+						AccessTierSpecified:             d.Get("access_tier") != "",
+						InfrastructureEncryptionEnabled: d.Get("infrastructure_encryption_enabled").(bool),
+					})
+					for _, issue := range issues {
+						if issue.Field == "access_tier" || issue.Field == "infrastructure_encryption_enabled" {
+							return issue
+						}
 					}
 				}
 
@@ -1263,6 +1301,18 @@ func resourceStorageAccount() *pluginsdk.Resource {
 				}
 				return false
 			}),
+			pluginsdk.ForceNewIf("queue_encryption_key_type", storageAccountEncryptionKeyTypeChangeRequiresNew("queue_encryption_key_type")),
+			pluginsdk.ForceNewIf("table_encryption_key_type", storageAccountEncryptionKeyTypeChangeRequiresNew("table_encryption_key_type")),
+			pluginsdk.CustomizeDiffShim(storageAccountCustomerManagedKeyIdentityDiff),
+			pluginsdk.CustomizeDiffShim(storageAccountCrossTenantReplicationDiff),
+			pluginsdk.CustomizeDiffShim(storageAccountEncryptionKeyTypeDiff),
+			pluginsdk.CustomizeDiffShim(storageAccountSftpLocalUserDiff),
+			pluginsdk.CustomizeDiffShim(storageAccountHnsRequiredFieldsDiff),
+			pluginsdk.CustomizeDiffShim(storageAccountAllowedCopyScopeDiff),
+			pluginsdk.CustomizeDiffShim(storageAccountDnsEndpointTypeBlobPropertiesDiff),
+			pluginsdk.CustomizeDiffShim(storageAccountBlobPropertiesDependenciesDiff),
+			pluginsdk.CustomizeDiffShim(storageAccountSubnetServiceEndpointDiff),
+			pluginsdk.CustomizeDiffShim(storageAccountEdgeZoneReplicationDiff),
 		),
 	}
 
@@ -1360,6 +1410,14 @@ func resourceStorageAccountCreate(d *pluginsdk.ResourceData, meta interface{}) e
 		Tags: tags.Expand(d.Get("tags").(map[string]interface{})),
 	}
 
+	if meta.(*clients.Client).Features.Storage.InheritResourceGroupTags {
+		resourceGroupTags, err := retrieveResourceGroupTags(ctx, meta.(*clients.Client).Resource.ResourceGroupsClient, commonids.NewResourceGroupID(id.SubscriptionId, id.ResourceGroupName))
+		if err != nil {
+			return fmt.Errorf("retrieving the Tags to inherit for %s: %+v", id, err)
+		}
+		payload.Tags = mergeResourceGroupTags(resourceGroupTags, payload.Tags)
+	}
+
 	if v := d.Get("allowed_copy_scope").(string); v != "" {
 		payload.Properties.AllowedCopyScope = pointer.To(storageaccounts.AllowedCopyScope(v))
 	}
@@ -1377,18 +1435,28 @@ func resourceStorageAccountCreate(d *pluginsdk.ResourceData, meta interface{}) e
 		payload.Properties.ImmutableStorageWithVersioning = expandAccountImmutabilityPolicy(v.([]interface{}))
 	}
 
-	// BlobStorage does not support ZRS
-	if accountKind == storageaccounts.KindBlobStorage && string(payload.Sku.Name) == string(storageaccounts.SkuNameStandardZRS) {
-		return fmt.Errorf("`account_replication_type` of `ZRS` isn't supported for Blob Storage accounts")
+	if err := validate.ReplicationTypeSupportedForKind(accountKind, replicationType); err != nil {
+		return err
 	}
 
 	accessTier, accessTierSetInConfig := d.GetOk("access_tier")
-	_, skuTierSupported := storageKindsSupportsSkuTier[accountKind]
-	if !skuTierSupported && accessTierSetInConfig {
-		keys := sortedKeysFromSlice(storageKindsSupportsSkuTier)
-		return fmt.Errorf("`access_tier` is only available for accounts of kind set to one of: %+v", strings.Join(keys, " / "))
+	// nolint staticcheck
+	largeFileShareEnabled, _ := d.GetOkExists("large_file_share_enabled")
+	infrastructureEncryption := d.Get("infrastructure_encryption_enabled").(bool)
+
+	if issues := validate.StorageAccountKindOptionsSupported(validate.StorageAccountKindOptions{
+		Kind:                            accountKind,
+		Tier:                            accountTier,
+		AccessTierSpecified:             accessTierSetInConfig,
+		HnsEnabled:                      isHnsEnabled,
+		InfrastructureEncryptionEnabled: infrastructureEncryption,
+		LargeFileShareEnabled:           largeFileShareEnabled.(bool),
+		NfsV3Enabled:                    nfsV3Enabled,
+	}); len(issues) > 0 {
+		return issues[0]
 	}
-	if skuTierSupported {
+
+	if validate.StorageAccountKindSupportsAccessTier(accountKind) {
 		if !accessTierSetInConfig {
 			// default to "Hot"
 			accessTier = string(storageaccounts.AccessTierHot)
@@ -1396,44 +1464,13 @@ func resourceStorageAccountCreate(d *pluginsdk.ResourceData, meta interface{}) e
 		payload.Properties.AccessTier = pointer.To(storageaccounts.AccessTier(accessTier.(string)))
 	}
 
-	if _, supportsHns := storageKindsSupportHns[accountKind]; !supportsHns && isHnsEnabled {
-		keys := sortedKeysFromSlice(storageKindsSupportHns)
-		return fmt.Errorf("`is_hns_enabled` can only be used for accounts with `kind` set to one of: %+v", strings.Join(keys, " / "))
-	}
-
-	// NFSv3 is supported for standard general-purpose v2 storage accounts and for premium block blob storage accounts.
-	// (https://docs.microsoft.com/en-us/azure/storage/blobs/network-file-system-protocol-support-how-to#step-5-create-and-configure-a-storage-account)
-	if nfsV3Enabled {
-		if !isHnsEnabled {
-			return fmt.Errorf("`nfsv3_enabled` can only be used when `is_hns_enabled` is `true`")
-		}
-
-		isPremiumTierAndBlockBlobStorageKind := accountTier == storageaccounts.SkuTierPremium && accountKind == storageaccounts.KindBlockBlobStorage
-		isStandardTierAndStorageV2Kind := accountTier == storageaccounts.SkuTierStandard && accountKind == storageaccounts.KindStorageVTwo
-		if !isPremiumTierAndBlockBlobStorageKind && !isStandardTierAndStorageV2Kind {
-			return fmt.Errorf("`nfsv3_enabled` can only be used with account tier `Standard` and account kind `StorageV2`, or account tier `Premium` and account kind `BlockBlobStorage`")
-		}
-	}
-
-	// AccountTier must be Premium for FileStorage
-	if accountKind == storageaccounts.KindFileStorage && accountTier != storageaccounts.SkuTierPremium {
-		return fmt.Errorf("`account_tier` must be `Premium` for File Storage accounts")
-	}
-
-	// nolint staticcheck
-	if v, ok := d.GetOkExists("large_file_share_enabled"); ok {
-		// @tombuildsstuff: we can't set this to `false` because the API returns:
-		//
-		// performing Create: unexpected status 400 (400 Bad Request) with error: InvalidRequestPropertyValue: The
-		// value 'Disabled' is not allowed for property largeFileSharesState. For more information, see -
-		// https://aka.ms/storageaccountlargefilesharestate
-		if v.(bool) {
-			if _, ok := storageKindsSupportLargeFileShares[accountKind]; !ok {
-				keys := sortedKeysFromSlice(storageKindsSupportLargeFileShares)
-				return fmt.Errorf("`large_file_shares_enabled` can only be set to `true` with `account_kind` set to one of: %+v", strings.Join(keys, " / "))
-			}
-			payload.Properties.LargeFileSharesState = pointer.To(storageaccounts.LargeFileSharesStateEnabled)
-		}
+	// @tombuildsstuff: we can't set this to `false` because the API returns:
+	//
+	// performing Create: unexpected status 400 (400 Bad Request) with error: InvalidRequestPropertyValue: The
+	// value 'Disabled' is not allowed for property largeFileSharesState. For more information, see -
+	// https://aka.ms/storageaccountlargefilesharestate
+	if largeFileShareEnabled.(bool) {
+		payload.Properties.LargeFileSharesState = pointer.To(storageaccounts.LargeFileSharesStateEnabled)
 	}
 
 	if v, ok := d.GetOk("routing"); ok {
@@ -1455,25 +1492,36 @@ func resourceStorageAccountCreate(d *pluginsdk.ResourceData, meta interface{}) e
 		return fmt.Errorf("expanding `customer_managed_key`: %+v", err)
 	}
 
-	infrastructureEncryption := d.Get("infrastructure_encryption_enabled").(bool)
-
 	if infrastructureEncryption {
-		validPremiumConfiguration := accountTier == storageaccounts.SkuTierPremium && (accountKind == storageaccounts.KindBlockBlobStorage) || accountKind == storageaccounts.KindFileStorage
-		validV2Configuration := accountKind == storageaccounts.KindStorageVTwo
-		if !(validPremiumConfiguration || validV2Configuration) {
-			return fmt.Errorf("`infrastructure_encryption_enabled` can only be used with account kind `StorageV2`, or account tier `Premium` and account kind is one of `BlockBlobStorage` or `FileStorage`")
-		}
 		encryption.RequireInfrastructureEncryption = &infrastructureEncryption
 	}
 
 	payload.Properties.Encryption = encryption
 
-	if err := client.CreateThenPoll(ctx, id, payload); err != nil {
-		return fmt.Errorf("creating %s: %+v", id, err)
+	createStartTime := time.Now()
+	result, err := client.Create(ctx, id, payload)
+	logStorageAccountRequestId("creating", id, result.HttpResponse)
+	if err != nil {
+		// the name of a deleted Storage Account can be retained by account-level soft delete
+		if response.WasConflict(result.HttpResponse) {
+			return storageAccountNameConflictError(ctx, storageClient.ResourceManager.DeletedAccounts, id, err)
+		}
+		return storageAccountCreateError(ctx, id, createStartTime, err)
+	}
+	if err := result.Poller.PollUntilDone(ctx); err != nil {
+		return storageAccountCreateError(ctx, id, createStartTime, err)
 	}
 
 	d.SetId(id.ID())
 
+	// the Storage Account can still be provisioning once the create has been polled, so wait for it prior to caching it
+	if err := WaitForStorageAccountProvisioning(ctx, client, id); err != nil {
+		if ctx.Err() == context.DeadlineExceeded {
+			return fmt.Errorf("timed out after %s - the Storage Account is still provisioning server-side, consider increasing `timeouts.create`: %+v", time.Since(createStartTime).Round(time.Second), err)
+		}
+		return err
+	}
+
 	// populate the cache
 	account, err := client.GetProperties(ctx, id, storageaccounts.DefaultGetPropertiesOperationOptions())
 	if err != nil {
@@ -1482,6 +1530,12 @@ func resourceStorageAccountCreate(d *pluginsdk.ResourceData, meta interface{}) e
 	if account.Model == nil {
 		return fmt.Errorf("retrieving %s: `model` was nil", id)
 	}
+
+	// the Principal ID of the System Assigned Identity can be empty immediately after it's been enabled
+	if account.Model, err = waitForStorageAccountIdentityPrincipalId(ctx, client, id, account.Model); err != nil {
+		return err
+	}
+
 	if err := storageClient.AddToCache(id, *account.Model); err != nil {
 		return fmt.Errorf("populating cache for %s: %+v", id, err)
 	}
@@ -1494,13 +1548,13 @@ func resourceStorageAccountCreate(d *pluginsdk.ResourceData, meta interface{}) e
 		return fmt.Errorf("unable to locate %q", id)
 	}
 
-	supportLevel := availableFunctionalityForAccount(accountKind, accountTier, replicationType)
+	supportLevel := helpers.AvailableFunctionalityForAccount(accountKind, accountTier, replicationType)
 	if err := waitForDataPlaneToBecomeAvailableForAccount(ctx, storageClient, dataPlaneAccount, supportLevel); err != nil {
 		return fmt.Errorf("waiting for the Data Plane for %s to become available: %+v", id, err)
 	}
 
 	if val, ok := d.GetOk("blob_properties"); ok {
-		if !supportLevel.supportBlob {
+		if !supportLevel.SupportBlob {
 			return fmt.Errorf("`blob_properties` aren't supported for account kind %q in sku tier %q", accountKind, accountTier)
 		}
 
@@ -1536,15 +1590,8 @@ func resourceStorageAccountCreate(d *pluginsdk.ResourceData, meta interface{}) e
 			}
 		}
 
-		// TODO: This is a temporary limitation on Storage service. Remove this check once the API supports this scenario.
-		// See https://github.com/hashicorp/terraform-provider-azurerm/pull/25450#discussion_r1542471667 for the context.
-		if dnsEndpointType == string(storageaccounts.DnsEndpointTypeAzureDnsZone) {
-			if blobProperties.Properties.RestorePolicy != nil && blobProperties.Properties.RestorePolicy.Enabled {
-				// Otherwise, API returns: "Required feature Global Dns is disabled"
-				// This is confirmed with the SRP team, where they said:
-				// > restorePolicy feature is incompatible with partitioned DNS
-				return fmt.Errorf("`blob_properties.restore_policy` can't be set when `dns_endpoint_type` is set to `%s`", storageaccounts.DnsEndpointTypeAzureDnsZone)
-			}
+		if err := validateAccountBlobPropertiesForDnsEndpointType(storageaccounts.DnsEndpointType(dnsEndpointType), val.([]interface{})); err != nil {
+			return err
 		}
 
 		if _, err = storageClient.ResourceManager.BlobService.SetServiceProperties(ctx, id, *blobProperties); err != nil {
@@ -1553,7 +1600,7 @@ func resourceStorageAccountCreate(d *pluginsdk.ResourceData, meta interface{}) e
 	}
 
 	if val, ok := d.GetOk("queue_properties"); ok {
-		if !supportLevel.supportQueue {
+		if !supportLevel.SupportQueue {
 			return fmt.Errorf("`queue_properties` aren't supported for account kind %q in sku tier %q", accountKind, accountTier)
 		}
 
@@ -1573,7 +1620,7 @@ func resourceStorageAccountCreate(d *pluginsdk.ResourceData, meta interface{}) e
 	}
 
 	if val, ok := d.GetOk("share_properties"); ok {
-		if !supportLevel.supportShare {
+		if !supportLevel.SupportShare {
 			return fmt.Errorf("`share_properties` aren't supported for account kind %q in sku tier %q", accountKind, accountTier)
 		}
 
@@ -1598,7 +1645,7 @@ func resourceStorageAccountCreate(d *pluginsdk.ResourceData, meta interface{}) e
 	}
 
 	if val, ok := d.GetOk("static_website"); ok {
-		if !supportLevel.supportStaticWebsite {
+		if !supportLevel.SupportStaticWebsite {
 			return fmt.Errorf("`static_website` aren't supported for account kind %q in sku tier %q", accountKind, accountTier)
 		}
 
@@ -1638,10 +1685,8 @@ func resourceStorageAccountUpdate(d *pluginsdk.ResourceData, meta interface{}) e
 	storageType := fmt.Sprintf("%s_%s", accountTier, replicationType)
 	accountKind := storageaccounts.Kind(d.Get("account_kind").(string))
 
-	if accountKind == storageaccounts.KindBlobStorage || accountKind == storageaccounts.KindStorage {
-		if storageType == string(storageaccounts.SkuNameStandardZRS) {
-			return fmt.Errorf("an `account_replication_type` of `ZRS` isn't supported for Blob Storage accounts")
-		}
+	if err := validate.ReplicationTypeSupportedForKind(accountKind, replicationType); err != nil {
+		return err
 	}
 
 	existing, err := client.GetProperties(ctx, *id, storageaccounts.DefaultGetPropertiesOperationOptions())
@@ -1704,7 +1749,10 @@ func resourceStorageAccountUpdate(d *pluginsdk.ResourceData, meta interface{}) e
 		props.AccessTier = pointer.To(storageaccounts.AccessTier(d.Get("access_tier").(string)))
 	}
 	if d.HasChange("allowed_copy_scope") {
-		props.AllowedCopyScope = pointer.To(storageaccounts.AllowedCopyScope(d.Get("allowed_copy_scope").(string)))
+		// removing `allowed_copy_scope` is rejected at plan time, since it's not possible to send a `null` value to clear it
+		if v := d.Get("allowed_copy_scope").(string); v != "" {
+			props.AllowedCopyScope = pointer.To(storageaccounts.AllowedCopyScope(v))
+		}
 	}
 	if d.HasChange("allow_nested_items_to_be_public") {
 		props.AllowBlobPublicAccess = pointer.To(d.Get("allow_nested_items_to_be_public").(bool))
@@ -1715,7 +1763,7 @@ func resourceStorageAccountUpdate(d *pluginsdk.ResourceData, meta interface{}) e
 	if d.HasChange("custom_domain") {
 		props.CustomDomain = expandAccountCustomDomain(d.Get("custom_domain").([]interface{}))
 	}
-	if d.HasChange("customer_managed_key") {
+	if d.HasChanges("customer_managed_key", "queue_encryption_key_type", "table_encryption_key_type") {
 		queueEncryptionKeyType := storageaccounts.KeyType(d.Get("queue_encryption_key_type").(string))
 		tableEncryptionKeyType := storageaccounts.KeyType(d.Get("table_encryption_key_type").(string))
 		encryptionRaw := d.Get("customer_managed_key").([]interface{})
@@ -1767,9 +1815,15 @@ func resourceStorageAccountUpdate(d *pluginsdk.ResourceData, meta interface{}) e
 			return fmt.Errorf("`large_file_share_enabled` cannot be disabled once it's been enabled")
 		}
 
-		if _, ok := storageKindsSupportLargeFileShares[accountKind]; !ok {
-			keys := sortedKeysFromSlice(storageKindsSupportLargeFileShares)
-			return fmt.Errorf("`large_file_shares_enabled` can only be set to `true` with `account_kind` set to one of: %+v", strings.Join(keys, " / "))
+		issues := validate.StorageAccountKindOptionsSupported(validate.StorageAccountKindOptions{
+			Kind:                  accountKind,
+			Tier:                  accountTier,
+			LargeFileShareEnabled: true,
+		})
+		for _, issue := range issues {
+			if issue.Field == "large_file_share_enabled" {
+				return issue
+			}
 		}
 		props.LargeFileSharesState = pointer.To(storageaccounts.LargeFileSharesStateEnabled)
 	}
@@ -1780,7 +1834,27 @@ func resourceStorageAccountUpdate(d *pluginsdk.ResourceData, meta interface{}) e
 		props.MinimumTlsVersion = pointer.To(storageaccounts.MinimumTlsVersion(d.Get("min_tls_version").(string)))
 	}
 	if d.HasChange("network_rules") {
-		props.NetworkAcls = expandAccountNetworkRules(d.Get("network_rules").([]interface{}), tenantId)
+		networkRules := expandAccountNetworkRules(d.Get("network_rules").([]interface{}), tenantId)
+		if d.Get("network_rules.0.merge_existing_rules").(bool) {
+			// retain any rules which are managed outside of this resource, e.g. by `azurerm_storage_account_network_rules`
+			oldNetworkRules, _ := d.GetChange("network_rules")
+			previousNetworkRules := expandAccountNetworkRules(oldNetworkRules.([]interface{}), tenantId)
+			networkRules = mergeAccountNetworkRules(existing.Model.Properties.NetworkAcls, previousNetworkRules, networkRules)
+		}
+		props.NetworkAcls = networkRules
+
+		if warning := accountNetworkRulesDefaultActionChangeWarning(existing.Model.Properties.NetworkAcls, networkRules, existing.Model.Properties.PrivateEndpointConnections); warning != "" {
+			log.Printf("[WARN] %s: %s", *id, warning)
+		}
+
+		// the networking api's only allow a single change to be made to a network layout at once, so lock the Virtual
+		// Networks referenced by both the existing and updated rules, as is done during deletion
+		virtualNetworkNames, err := accountNetworkRulesVirtualNetworkNames(existing.Model.Properties.NetworkAcls, networkRules)
+		if err != nil {
+			return err
+		}
+		locks.MultipleByName(&virtualNetworkNames, network.VirtualNetworkResourceName)
+		defer locks.UnlockMultipleByName(&virtualNetworkNames, network.VirtualNetworkResourceName)
 	}
 	if d.HasChange("public_network_access_enabled") {
 		publicNetworkAccess := storageaccounts.PublicNetworkAccessDisabled
@@ -1825,11 +1899,39 @@ func resourceStorageAccountUpdate(d *pluginsdk.ResourceData, meta interface{}) e
 	}
 	if d.HasChange("tags") {
 		payload.Tags = tags.Expand(d.Get("tags").(map[string]interface{}))
+
+		// otherwise the Tags inherited from the Resource Group would be removed
+		if meta.(*clients.Client).Features.Storage.InheritResourceGroupTags {
+			resourceGroupTags, err := retrieveResourceGroupTags(ctx, meta.(*clients.Client).Resource.ResourceGroupsClient, commonids.NewResourceGroupID(id.SubscriptionId, id.ResourceGroupName))
+			if err != nil {
+				return fmt.Errorf("retrieving the Tags to inherit for %s: %+v", *id, err)
+			}
+			payload.Tags = mergeResourceGroupTags(resourceGroupTags, payload.Tags)
+		}
+
+		// otherwise the Tags added by Azure (e.g. `hidden-link:`) would be removed
+		payload.Tags = mergeIgnoredTags(meta.(*clients.Client).Features.Storage.IgnoredTagPrefixes, existing.Model.Tags, payload.Tags)
 	}
 
-	if err := client.CreateThenPoll(ctx, *id, payload); err != nil {
+	result, err := client.Create(ctx, *id, payload)
+	logStorageAccountRequestId("updating", *id, result.HttpResponse)
+	if err != nil {
 		return fmt.Errorf("updating %s: %+v", id, err)
 	}
+	if err := result.Poller.PollUntilDone(ctx); err != nil {
+		return fmt.Errorf("updating %s: %+v", id, err)
+	}
+
+	// the Principal ID of the System Assigned Identity can be empty immediately after it's been enabled
+	if d.HasChange("identity") {
+		updated, err := client.GetProperties(ctx, *id, storageaccounts.DefaultGetPropertiesOperationOptions())
+		if err != nil {
+			return fmt.Errorf("retrieving %s: %+v", *id, err)
+		}
+		if _, err := waitForStorageAccountIdentityPrincipalId(ctx, client, *id, updated.Model); err != nil {
+			return err
+		}
+	}
 
 	// azure_files_authentication must be the last to be updated, cause it'll occupy the storage account for several minutes after receiving the response 200 OK. Issue: https://github.com/Azure/azure-rest-api-specs/issues/11272
 	if d.HasChange("azure_files_authentication") {
@@ -1844,7 +1946,9 @@ func resourceStorageAccountUpdate(d *pluginsdk.ResourceData, meta interface{}) e
 					},
 				},
 			}
-			if _, err := client.Update(ctx, *id, dsNone); err != nil {
+			resp, err := client.Update(ctx, *id, dsNone)
+			logStorageAccountRequestId("updating `azure_files_authentication` for", *id, resp.HttpResponse)
+			if err != nil {
 				return fmt.Errorf("updating `azure_files_authentication` for %s: %+v", *id, err)
 			}
 		}
@@ -1859,16 +1963,23 @@ func resourceStorageAccountUpdate(d *pluginsdk.ResourceData, meta interface{}) e
 			},
 		}
 
-		if _, err := client.Update(ctx, *id, opts); err != nil {
+		resp, err := client.Update(ctx, *id, opts)
+		logStorageAccountRequestId("updating `azure_files_authentication` for", *id, resp.HttpResponse)
+		if err != nil {
 			return fmt.Errorf("updating `azure_files_authentication` for %s: %+v", *id, err)
 		}
+
+		// the Storage Account continues provisioning for several minutes after this has been applied
+		if err := WaitForStorageAccountProvisioning(ctx, client, *id); err != nil {
+			return err
+		}
 	}
 
 	// Followings are updates to the sub-services
-	supportLevel := availableFunctionalityForAccount(accountKind, accountTier, replicationType)
+	supportLevel := helpers.AvailableFunctionalityForAccount(accountKind, accountTier, replicationType)
 
 	if d.HasChange("blob_properties") {
-		if !supportLevel.supportBlob {
+		if !supportLevel.SupportBlob {
 			return fmt.Errorf("`blob_properties` aren't supported for account kind %q in sku tier %q", accountKind, accountTier)
 		}
 
@@ -1881,36 +1992,42 @@ func resourceStorageAccountUpdate(d *pluginsdk.ResourceData, meta interface{}) e
 			return fmt.Errorf("`versioning_enabled` can't be true when `is_hns_enabled` is true")
 		}
 
-		// Disable restore_policy first. Disabling restore_policy and while setting delete_retention_policy.allow_permanent_delete to true cause error.
-		// Issue : https://github.com/Azure/azure-rest-api-specs/issues/11237
-		if v := d.Get("blob_properties.0.restore_policy"); d.HasChange("blob_properties.0.restore_policy") && len(v.([]interface{})) == 0 {
-			log.Print("[DEBUG] Disabling RestorePolicy prior to changing DeleteRetentionPolicy")
-			blobPayload := blobservice.BlobServiceProperties{
-				Properties: &blobservice.BlobServicePropertiesProperties{
-					RestorePolicy: expandAccountBlobPropertiesRestorePolicy(v.([]interface{})),
-				},
-			}
-			if _, err := storageClient.ResourceManager.BlobService.SetServiceProperties(ctx, *id, blobPayload); err != nil {
-				return fmt.Errorf("updating Azure Storage Account blob restore policy %q: %+v", id.StorageAccountName, err)
+		// Lifecycle Management rules based on the last access time silently stop applying when tracking is disabled
+		if d.HasChange("blob_properties.0.last_access_time_enabled") {
+			if o, n := d.GetChange("blob_properties.0.last_access_time_enabled"); o.(bool) && !n.(bool) {
+				log.Printf("[WARN] disabling `last_access_time_enabled` for %s - any Storage Management Policy rules based on the last access time will no longer be applied", *id)
 			}
 		}
 
-		if d.Get("dns_endpoint_type").(string) == string(storageaccounts.DnsEndpointTypeAzureDnsZone) {
-			if blobProperties.Properties.RestorePolicy != nil && blobProperties.Properties.RestorePolicy.Enabled {
-				// Otherwise, API returns: "Required feature Global Dns is disabled"
-				// This is confirmed with the SRP team, where they said:
-				// > restorePolicy feature is incompatible with partitioned DNS
-				return fmt.Errorf("`blob_properties.restore_policy` can't be set when `dns_endpoint_type` is set to `%s`", storageaccounts.DnsEndpointTypeAzureDnsZone)
+		if err := validateAccountBlobPropertiesForDnsEndpointType(storageaccounts.DnsEndpointType(d.Get("dns_endpoint_type").(string)), d.Get("blob_properties").([]interface{})); err != nil {
+			return err
+		}
+
+		// the ordering is determined from the current Blob Service Properties rather than the state, which may have drifted
+		existingBlobProperties, err := storageClient.ResourceManager.BlobService.GetServiceProperties(ctx, *id)
+		if err != nil {
+			return fmt.Errorf("retrieving `blob_properties` for %s: %+v", *id, err)
+		}
+		var existingBlobServiceProperties *blobservice.BlobServicePropertiesProperties
+		if existingBlobProperties.Model != nil {
+			existingBlobServiceProperties = existingBlobProperties.Model.Properties
+		}
+
+		for _, payload := range orderedAccountBlobServicePropertiesUpdates(existingBlobServiceProperties, blobProperties.Properties) {
+			if _, err = storageClient.ResourceManager.BlobService.SetServiceProperties(ctx, *id, payload); err != nil {
+				return fmt.Errorf("updating `blob_properties` for %s: %+v", *id, err)
 			}
 		}
 
-		if _, err = storageClient.ResourceManager.BlobService.SetServiceProperties(ctx, *id, *blobProperties); err != nil {
-			return fmt.Errorf("updating `blob_properties` for %s: %+v", *id, err)
+		if accountBlobRestorePolicyDisabled(existingBlobServiceProperties, blobProperties.Properties) {
+			if err := waitForAccountBlobRestorePolicyDisabled(ctx, storageClient.ResourceManager.BlobService, *id); err != nil {
+				return err
+			}
 		}
 	}
 
 	if d.HasChange("queue_properties") {
-		if !supportLevel.supportQueue {
+		if !supportLevel.SupportQueue {
 			return fmt.Errorf("`queue_properties` aren't supported for account kind %q in sku tier %q", accountKind, accountTier)
 		}
 
@@ -1938,7 +2055,7 @@ func resourceStorageAccountUpdate(d *pluginsdk.ResourceData, meta interface{}) e
 	}
 
 	if d.HasChange("share_properties") {
-		if !supportLevel.supportShare {
+		if !supportLevel.SupportShare {
 			return fmt.Errorf("`share_properties` aren't supported for account kind %q in sku tier %q", accountKind, accountTier)
 		}
 
@@ -1961,7 +2078,7 @@ func resourceStorageAccountUpdate(d *pluginsdk.ResourceData, meta interface{}) e
 	}
 
 	if d.HasChange("static_website") {
-		if !supportLevel.supportStaticWebsite {
+		if !supportLevel.SupportStaticWebsite {
 			return fmt.Errorf("`static_website` aren't supported for account kind %q in sku tier %q", accountKind, accountTier)
 		}
 
@@ -1995,10 +2112,8 @@ func resourceStorageAccountRead(d *pluginsdk.ResourceData, meta interface{}) err
 	ctx, cancel := timeouts.ForRead(meta.(*clients.Client).StopContext, d)
 	defer cancel()
 
-	storageDomainSuffix, ok := meta.(*clients.Client).Account.Environment.Storage.DomainSuffix()
-	if !ok {
-		return fmt.Errorf("could not determine Storage domain suffix for environment %q", meta.(*clients.Client).Account.Environment.Name)
-	}
+	// this takes into account the `storage_domain_suffix` override within the Provider block, if specified
+	storageDomainSuffix := storageClient.StorageDomainSuffix
 
 	id, err := commonids.ParseStorageAccountID(d.Id())
 	if err != nil {
@@ -2006,6 +2121,7 @@ func resourceStorageAccountRead(d *pluginsdk.ResourceData, meta interface{}) err
 	}
 
 	resp, err := client.GetProperties(ctx, *id, storageaccounts.DefaultGetPropertiesOperationOptions())
+	logStorageAccountRequestId("retrieving", *id, resp.HttpResponse)
 	if err != nil {
 		if response.WasNotFound(resp.HttpResponse) {
 			d.SetId("")
@@ -2016,33 +2132,39 @@ func resourceStorageAccountRead(d *pluginsdk.ResourceData, meta interface{}) err
 	}
 
 	// we then need to find the storage account
-	account, err := storageClient.FindAccount(ctx, id.SubscriptionId, id.StorageAccountName)
+	account, err := findAccountForRead(ctx, storageClient, *id, resp.Model)
 	if err != nil {
-		return fmt.Errorf("retrieving %s: %+v", *id, err)
-	}
-	if account == nil {
-		return fmt.Errorf("unable to locate %q", id)
+		return err
 	}
 
-	listKeysOpts := storageaccounts.DefaultListKeysOperationOptions()
-	listKeysOpts.Expand = pointer.To(storageaccounts.ListKeyExpandKerb)
-	keys, err := client.ListKeys(ctx, *id, listKeysOpts)
-	if err != nil {
-		hasWriteLock := response.WasConflict(keys.HttpResponse)
-		doesntHavePermissions := response.WasForbidden(keys.HttpResponse) || response.WasStatusCode(keys.HttpResponse, http.StatusUnauthorized)
-		if !hasWriteLock && !doesntHavePermissions {
-			return fmt.Errorf("listing Keys for %s: %+v", id, err)
+	// the keys (and therefore the connection strings) are left empty when the `skip_key_retrieval` feature is enabled
+	var keys storageaccounts.ListKeysOperationResponse
+	if !meta.(*clients.Client).Features.Storage.SkipKeyRetrieval {
+		keys, err = client.ListKeys(ctx, *id, storageAccountListKeysOptions(resp.Model))
+		if err != nil {
+			// the Storage Account may have been deleted since it was retrieved above
+			if response.WasNotFound(keys.HttpResponse) {
+				log.Printf("[DEBUG] %s was not found when listing Keys - removing from state", *id)
+				d.SetId("")
+				return nil
+			}
+
+			hasWriteLock := response.WasConflict(keys.HttpResponse)
+			doesntHavePermissions := response.WasForbidden(keys.HttpResponse) || response.WasStatusCode(keys.HttpResponse, http.StatusUnauthorized)
+			if !hasWriteLock && !doesntHavePermissions {
+				return fmt.Errorf("listing Keys for %s: %+v", id, err)
+			}
 		}
 	}
 
 	d.Set("name", id.StorageAccountName)
 	d.Set("resource_group_name", id.ResourceGroupName)
 
-	supportLevel := storageAccountServiceSupportLevel{
-		supportBlob:          false,
-		supportQueue:         false,
-		supportShare:         false,
-		supportStaticWebsite: false,
+	supportLevel := helpers.StorageAccountServiceSupportLevel{
+		SupportBlob:          false,
+		SupportQueue:         false,
+		SupportShare:         false,
+		SupportStaticWebsite: false,
 	}
 	var accountKind storageaccounts.Kind
 	var primaryEndpoints *storageaccounts.Endpoints
@@ -2057,7 +2179,7 @@ func resourceStorageAccountRead(d *pluginsdk.ResourceData, meta interface{}) err
 		var accountTier storageaccounts.SkuTier
 		accountReplicationType := ""
 		if sku := model.Sku; sku != nil {
-			accountReplicationType = strings.Split(string(sku.Name), "_")[1]
+			accountReplicationType = storageAccountReplicationTypeFromSkuName(sku.Name)
 			if sku.Tier != nil {
 				accountTier = *sku.Tier
 			}
@@ -2078,7 +2200,12 @@ func resourceStorageAccountRead(d *pluginsdk.ResourceData, meta interface{}) err
 			if err := d.Set("azure_files_authentication", flattenAccountAzureFilesAuthentication(props.AzureFilesIdentityBasedAuthentication)); err != nil {
 				return fmt.Errorf("setting `azure_files_authentication`: %+v", err)
 			}
-			d.Set("cross_tenant_replication_enabled", pointer.From(props.AllowCrossTenantReplication))
+			crossTenantReplicationEnabled := pointer.From(props.AllowCrossTenantReplication)
+			if !storageAccountSupportsObjectReplication(accountKind, accountTier) {
+				// the API ignores this for accounts which can't use Object Replication, so keep the existing value to avoid a diff
+				crossTenantReplicationEnabled = d.Get("cross_tenant_replication_enabled").(bool)
+			}
+			d.Set("cross_tenant_replication_enabled", crossTenantReplicationEnabled)
 			d.Set("https_traffic_only_enabled", pointer.From(props.SupportsHTTPSTrafficOnly))
 			if !features.FourPointOhBeta() {
 				d.Set("enable_https_traffic_only", pointer.From(props.SupportsHTTPSTrafficOnly))
@@ -2086,11 +2213,16 @@ func resourceStorageAccountRead(d *pluginsdk.ResourceData, meta interface{}) err
 			d.Set("is_hns_enabled", pointer.From(props.IsHnsEnabled))
 			d.Set("nfsv3_enabled", pointer.From(props.IsNfsV3Enabled))
 			d.Set("primary_location", pointer.From(props.PrimaryLocation))
-			if err := d.Set("routing", flattenAccountRoutingPreference(props.RoutingPreference)); err != nil {
+			if err := d.Set("routing", flattenAccountRoutingPreference(props.RoutingPreference, d.Get("routing").([]interface{}))); err != nil {
 				return fmt.Errorf("setting `routing`: %+v", err)
 			}
 			d.Set("secondary_location", pointer.From(props.SecondaryLocation))
-			d.Set("sftp_enabled", pointer.From(props.IsSftpEnabled))
+			// older Storage Accounts may omit `isSftpEnabled`, in which case keep the existing value rather than assuming `false`
+			sftpEnabled := d.Get("sftp_enabled").(bool)
+			if props.IsSftpEnabled != nil {
+				sftpEnabled = *props.IsSftpEnabled
+			}
+			d.Set("sftp_enabled", sftpEnabled)
 
 			// NOTE: The Storage API returns `null` rather than the default value in the API response for existing
 			// resources when a new field gets added - meaning we need to default the values below.
@@ -2124,11 +2256,7 @@ func resourceStorageAccountRead(d *pluginsdk.ResourceData, meta interface{}) err
 			}
 			d.Set("large_file_share_enabled", largeFileShareEnabled)
 
-			minTlsVersion := string(storageaccounts.MinimumTlsVersionTLSOneZero)
-			if props.MinimumTlsVersion != nil {
-				minTlsVersion = string(*props.MinimumTlsVersion)
-			}
-			d.Set("min_tls_version", minTlsVersion)
+			d.Set("min_tls_version", flattenAccountMinimumTlsVersion(props.MinimumTlsVersion))
 
 			publicNetworkAccessEnabled := true
 			if props.PublicNetworkAccess != nil && *props.PublicNetworkAccess == storageaccounts.PublicNetworkAccessDisabled {
@@ -2142,13 +2270,28 @@ func resourceStorageAccountRead(d *pluginsdk.ResourceData, meta interface{}) err
 			}
 			d.Set("shared_access_key_enabled", allowSharedKeyAccess)
 
-			if err := d.Set("custom_domain", flattenAccountCustomDomain(props.CustomDomain)); err != nil {
+			customDomain := flattenAccountCustomDomain(props.CustomDomain)
+			if len(customDomain) > 0 {
+				// `use_subdomain` isn't returned by the API, so keep the value from the state
+				customDomain[0].(map[string]interface{})["use_subdomain"] = d.Get("custom_domain.0.use_subdomain").(bool)
+			}
+			if err := d.Set("custom_domain", customDomain); err != nil {
 				return fmt.Errorf("setting `custom_domain`: %+v", err)
 			}
 			if err := d.Set("immutability_policy", flattenAccountImmutabilityPolicy(props.ImmutableStorageWithVersioning)); err != nil {
 				return fmt.Errorf("setting `immutability_policy`: %+v", err)
 			}
-			if err := d.Set("network_rules", flattenAccountNetworkRules(props.NetworkAcls)); err != nil {
+			d.Set("has_immutability_policy", accountHasImmutabilityPolicy(props.ImmutableStorageWithVersioning))
+			networkRules := props.NetworkAcls
+			mergeExistingNetworkRules := d.Get("network_rules.0.merge_existing_rules").(bool)
+			if mergeExistingNetworkRules {
+				networkRules = managedAccountNetworkRules(networkRules, expandAccountNetworkRules(d.Get("network_rules").([]interface{}), ""))
+			}
+			flattenedNetworkRules := flattenAccountNetworkRules(networkRules)
+			if len(flattenedNetworkRules) > 0 {
+				flattenedNetworkRules[0].(map[string]interface{})["merge_existing_rules"] = mergeExistingNetworkRules
+			}
+			if err := d.Set("network_rules", flattenedNetworkRules); err != nil {
 				return fmt.Errorf("setting `network_rules`: %+v", err)
 			}
 
@@ -2181,7 +2324,7 @@ func resourceStorageAccountRead(d *pluginsdk.ResourceData, meta interface{}) err
 				return fmt.Errorf("setting `sas_policy`: %+v", err)
 			}
 
-			supportLevel = availableFunctionalityForAccount(accountKind, accountTier, accountReplicationType)
+			supportLevel = helpers.AvailableFunctionalityForAccount(accountKind, accountTier, accountReplicationType)
 		}
 
 		flattenedIdentity, err := identity.FlattenLegacySystemAndUserAssignedMap(model.Identity)
@@ -2192,7 +2335,16 @@ func resourceStorageAccountRead(d *pluginsdk.ResourceData, meta interface{}) err
 			return fmt.Errorf("setting `identity`: %+v", err)
 		}
 
-		if err := tags.FlattenAndSet(d, model.Tags); err != nil {
+		accountTags := model.Tags
+		if meta.(*clients.Client).Features.Storage.InheritResourceGroupTags {
+			resourceGroupTags, err := retrieveResourceGroupTags(ctx, meta.(*clients.Client).Resource.ResourceGroupsClient, commonids.NewResourceGroupID(id.SubscriptionId, id.ResourceGroupName))
+			if err != nil {
+				return fmt.Errorf("retrieving the inherited Tags for %s: %+v", *id, err)
+			}
+			accountTags = removeInheritedResourceGroupTags(resourceGroupTags, accountTags, d.Get("tags").(map[string]interface{}))
+		}
+		accountTags = removeIgnoredTags(meta.(*clients.Client).Features.Storage.IgnoredTagPrefixes, accountTags, d.Get("tags").(map[string]interface{}))
+		if err := tags.FlattenAndSet(d, accountTags); err != nil {
 			return err
 		}
 	}
@@ -2206,13 +2358,13 @@ func resourceStorageAccountRead(d *pluginsdk.ResourceData, meta interface{}) err
 	if keys.Model != nil && keys.Model.Keys != nil {
 		storageAccountKeys = *keys.Model.Keys
 	}
-	keysAndConnectionStrings := flattenAccountAccessKeysAndConnectionStrings(id.StorageAccountName, *storageDomainSuffix, storageAccountKeys, endpoints)
+	keysAndConnectionStrings := flattenAccountAccessKeysAndConnectionStrings(id.StorageAccountName, storageDomainSuffix, d.Get("shared_access_key_enabled").(bool), storageAccountKeys, endpoints)
 	if err := keysAndConnectionStrings.set(d); err != nil {
 		return err
 	}
 
 	blobProperties := make([]interface{}, 0)
-	if supportLevel.supportBlob {
+	if supportLevel.SupportBlob {
 		blobProps, err := storageClient.ResourceManager.BlobService.GetServiceProperties(ctx, *id)
 		if err != nil {
 			return fmt.Errorf("reading blob properties for %s: %+v", *id, err)
@@ -2224,19 +2376,26 @@ func resourceStorageAccountRead(d *pluginsdk.ResourceData, meta interface{}) err
 		return fmt.Errorf("setting `blob_properties` for %s: %+v", *id, err)
 	}
 
-	queueProperties := make([]interface{}, 0)
-	if supportLevel.supportQueue {
-		queueClient, err := storageClient.QueuesDataPlaneClient(ctx, *account, storageClient.DataPlaneOperationSupportingAnyAuthMethod())
+	// when public access is disallowed at the account level, surface any containers which still have a public access
+	// level configured (and would become public again should this be re-enabled) so that these can be remediated
+	dataPlaneAccessOnReadEnabled := storageAccountDataPlaneAccessOnReadEnabled(d, meta.(*clients.Client).Features.Storage.DataPlaneAccessOnReadDefault)
+	publicContainers := make([]string, 0)
+	if supportLevel.SupportBlob && !d.Get("allow_nested_items_to_be_public").(bool) && dataPlaneAccessOnReadEnabled {
+		publicContainers, err = storageAccountPublicContainers(ctx, storageClient.ResourceManager.BlobContainers, *id)
 		if err != nil {
-			return fmt.Errorf("building Queues Client: %s", err)
+			return fmt.Errorf("listing public containers for %s: %+v", *id, err)
 		}
+	}
+	if err := d.Set("public_containers", publicContainers); err != nil {
+		return fmt.Errorf("setting `public_containers` for %s: %+v", *id, err)
+	}
 
-		queueProps, err := queueClient.GetServiceProperties(ctx)
+	queueProperties := make([]interface{}, 0)
+	if supportLevel.SupportQueue {
+		queueProperties, err = readAccountQueueProperties(ctx, storageClient, account, *id)
 		if err != nil {
-			return fmt.Errorf("retrieving queue properties for %s: %+v", *id, err)
+			return err
 		}
-
-		queueProperties = flattenAccountQueueProperties(queueProps)
 	}
 
 	if err := d.Set("queue_properties", queueProperties); err != nil {
@@ -2244,7 +2403,7 @@ func resourceStorageAccountRead(d *pluginsdk.ResourceData, meta interface{}) err
 	}
 
 	shareProperties := make([]interface{}, 0)
-	if supportLevel.supportShare {
+	if supportLevel.SupportShare {
 		shareProps, err := storageClient.ResourceManager.FileService.GetServiceProperties(ctx, *id)
 		if err != nil {
 			return fmt.Errorf("retrieving share properties for %s: %+v", *id, err)
@@ -2257,18 +2416,11 @@ func resourceStorageAccountRead(d *pluginsdk.ResourceData, meta interface{}) err
 	}
 
 	staticWebsiteProperties := make([]interface{}, 0)
-	if supportLevel.supportStaticWebsite {
-		accountsClient, err := storageClient.AccountsDataPlaneClient(ctx, *account, storageClient.DataPlaneOperationSupportingAnyAuthMethod())
+	if supportLevel.SupportStaticWebsite {
+		staticWebsiteProperties, err = readAccountStaticWebsiteProperties(ctx, storageClient, account, *id)
 		if err != nil {
-			return fmt.Errorf("building Accounts Data Plane Client: %s", err)
-		}
-
-		staticWebsiteProps, err := accountsClient.GetServiceProperties(ctx, id.StorageAccountName)
-		if err != nil {
-			return fmt.Errorf("retrieving static website properties for %s: %+v", *id, err)
+			return err
 		}
-
-		staticWebsiteProperties = flattenAccountStaticWebsiteProperties(staticWebsiteProps)
 	}
 	if err := d.Set("static_website", staticWebsiteProperties); err != nil {
 		return fmt.Errorf("setting `static_website`: %+v", err)
@@ -2277,6 +2429,81 @@ func resourceStorageAccountRead(d *pluginsdk.ResourceData, meta interface{}) err
 	return nil
 }
 
+// readAccountQueueProperties retrieves the Queue Service Properties using a separate timeout, returning empty properties
+// when this times out so that an unresponsive Queue endpoint doesn't fail the entire read.
+func readAccountQueueProperties(ctx context.Context, storageClient *client.Client, account *client.AccountDetails, id commonids.StorageAccountId) ([]interface{}, error) {
+	dataPlaneCtx, cancel := dataPlaneReadContext(ctx)
+	defer cancel()
+
+	queueClient, err := storageClient.QueuesDataPlaneClient(dataPlaneCtx, *account, storageClient.DataPlaneOperationSupportingAnyAuthMethod())
+	if err != nil {
+		if dataPlaneReadTimedOut(ctx, dataPlaneCtx) {
+			log.Printf("[WARN] timed out building the Queues Client for %s - `queue_properties` will be empty: %+v", id, err)
+			return make([]interface{}, 0), nil
+		}
+		return nil, fmt.Errorf("building Queues Client: %s", err)
+	}
+
+	queueProps, err := queueClient.GetServiceProperties(dataPlaneCtx)
+	if err != nil {
+		if dataPlaneReadTimedOut(ctx, dataPlaneCtx) {
+			log.Printf("[WARN] timed out retrieving queue properties for %s - `queue_properties` will be empty: %+v", id, err)
+			return make([]interface{}, 0), nil
+		}
+		return nil, fmt.Errorf("retrieving queue properties for %s: %+v", id, err)
+	}
+
+	return flattenAccountQueueProperties(queueProps), nil
+}
+
+// readAccountStaticWebsiteProperties retrieves the Static Website Properties using a separate timeout, returning empty
+// properties when this times out so that an unresponsive Blob endpoint doesn't fail the entire read.
+func readAccountStaticWebsiteProperties(ctx context.Context, storageClient *client.Client, account *client.AccountDetails, id commonids.StorageAccountId) ([]interface{}, error) {
+	dataPlaneCtx, cancel := dataPlaneReadContext(ctx)
+	defer cancel()
+
+	accountsClient, err := storageClient.AccountsDataPlaneClient(dataPlaneCtx, *account, storageClient.DataPlaneOperationSupportingAnyAuthMethod())
+	if err != nil {
+		if dataPlaneReadTimedOut(ctx, dataPlaneCtx) {
+			log.Printf("[WARN] timed out building the Accounts Data Plane Client for %s - `static_website` will be empty: %+v", id, err)
+			return make([]interface{}, 0), nil
+		}
+		return nil, fmt.Errorf("building Accounts Data Plane Client: %s", err)
+	}
+
+	staticWebsiteProps, err := accountsClient.GetServiceProperties(dataPlaneCtx, id.StorageAccountName)
+	if err != nil {
+		if dataPlaneReadTimedOut(ctx, dataPlaneCtx) {
+			log.Printf("[WARN] timed out retrieving static website properties for %s - `static_website` will be empty: %+v", id, err)
+			return make([]interface{}, 0), nil
+		}
+		return nil, fmt.Errorf("retrieving static website properties for %s: %+v", id, err)
+	}
+
+	return flattenAccountStaticWebsiteProperties(staticWebsiteProps), nil
+}
+
+// storageAccountDataPlaneAccessOnReadEnabled returns the value of `data_plane_access_on_read_enabled` from the
+// configuration - falling back to the `data_plane_access_on_read_default` feature when this isn't specified (or when
+// importing), since it's not returned by the API.
+func storageAccountDataPlaneAccessOnReadEnabled(d *pluginsdk.ResourceData, defaultValue bool) bool {
+	if config := d.GetRawConfig(); !config.IsNull() {
+		if v := config.GetAttr("data_plane_access_on_read_enabled"); v.IsKnown() && !v.IsNull() {
+			return v.True()
+		}
+		return defaultValue
+	}
+
+	// the configuration isn't available during a refresh - since this value is never set by the Read, the state only
+	// contains it when it's been specified in the configuration
+	// nolint staticcheck
+	if v, ok := d.GetOkExists("data_plane_access_on_read_enabled"); ok {
+		return v.(bool)
+	}
+
+	return defaultValue
+}
+
 func resourceStorageAccountDelete(d *pluginsdk.ResourceData, meta interface{}) error {
 	storageClient := meta.(*clients.Client).Storage
 	client := storageClient.ResourceManager.StorageAccounts
@@ -2300,32 +2527,24 @@ func resourceStorageAccountDelete(d *pluginsdk.ResourceData, meta interface{}) e
 	}
 
 	// the networking api's only allow a single change to be made to a network layout at once, so let's lock to handle that
-	virtualNetworkNames := make([]string, 0)
-	if model := existing.Model; model != nil && model.Properties != nil {
-		if acls := model.Properties.NetworkAcls; acls != nil {
-			if vnr := acls.VirtualNetworkRules; vnr != nil {
-				for _, v := range *vnr {
-					subnetId, err := commonids.ParseSubnetIDInsensitively(v.Id)
-					if err != nil {
-						return err
-					}
-
-					networkName := subnetId.VirtualNetworkName
-					for _, virtualNetworkName := range virtualNetworkNames {
-						if networkName == virtualNetworkName {
-							continue
-						}
-					}
-					virtualNetworkNames = append(virtualNetworkNames, networkName)
-				}
-			}
+	// unless the `skip_virtual_network_locking_on_destroy` feature asserts there are no concurrent changes to the network
+	if !meta.(*clients.Client).Features.Storage.SkipVirtualNetworkLockingOnDestroy {
+		var existingNetworkRules *storageaccounts.NetworkRuleSet
+		if model := existing.Model; model != nil && model.Properties != nil {
+			existingNetworkRules = model.Properties.NetworkAcls
+		}
+		virtualNetworkNames, err := accountNetworkRulesVirtualNetworkNames(existingNetworkRules)
+		if err != nil {
+			return err
 		}
-	}
 
-	locks.MultipleByName(&virtualNetworkNames, network.VirtualNetworkResourceName)
-	defer locks.UnlockMultipleByName(&virtualNetworkNames, network.VirtualNetworkResourceName)
+		locks.MultipleByName(&virtualNetworkNames, network.VirtualNetworkResourceName)
+		defer locks.UnlockMultipleByName(&virtualNetworkNames, network.VirtualNetworkResourceName)
+	}
 
-	if _, err := client.Delete(ctx, *id); err != nil {
+	resp, err := client.Delete(ctx, *id)
+	logStorageAccountRequestId("deleting", *id, resp.HttpResponse)
+	if err != nil {
 		return fmt.Errorf("deleting %s: %+v", *id, err)
 	}
 
@@ -2335,6 +2554,351 @@ func resourceStorageAccountDelete(d *pluginsdk.ResourceData, meta interface{}) e
 	return nil
 }
 
+// storageAccountEncryptionKeyTypeChangeRequiresNew returns whether a change to the Queue/Table encryption key type requires
+// the Storage Account to be recreated - since only a `StorageV2` account can switch from `Service` to `Account` in-place
+func storageAccountEncryptionKeyTypeChangeRequiresNew(key string) pluginsdk.ResourceConditionFunc {
+	return func(ctx context.Context, d *pluginsdk.ResourceDiff, meta interface{}) bool {
+		if !d.HasChange(key) {
+			return false
+		}
+
+		if storageaccounts.Kind(d.Get("account_kind").(string)) != storageaccounts.KindStorageVTwo {
+			return true
+		}
+
+		oldKeyType, newKeyType := d.GetChange(key)
+		return oldKeyType.(string) != string(storageaccounts.KeyTypeService) || newKeyType.(string) != string(storageaccounts.KeyTypeAccount)
+	}
+}
+
+// storageAccountSupportsObjectReplication returns whether Object Replication (and therefore Cross Tenant Replication)
+// is available, which is only the case for Standard StorageV2 and Premium BlockBlobStorage accounts.
+func storageAccountSupportsObjectReplication(kind storageaccounts.Kind, tier storageaccounts.SkuTier) bool {
+	return (kind == storageaccounts.KindStorageVTwo && tier == storageaccounts.SkuTierStandard) || kind == storageaccounts.KindBlockBlobStorage
+}
+
+// storageAccountCrossTenantReplicationDiff raises an error when `cross_tenant_replication_enabled` is explicitly enabled
+// for an account which can't use Object Replication, since the API silently ignores the value in this case.
+func storageAccountCrossTenantReplicationDiff(ctx context.Context, d *pluginsdk.ResourceDiff, meta interface{}) error {
+	config := d.GetRawConfig()
+	if config.IsNull() {
+		return nil
+	}
+
+	// the default for this field is `true` prior to 4.0, so only the value from the config is considered here
+	v := config.GetAttr("cross_tenant_replication_enabled")
+	if v.IsNull() || !v.IsKnown() || !v.True() {
+		return nil
+	}
+
+	accountKind := storageaccounts.Kind(d.Get("account_kind").(string))
+	accountTier := storageaccounts.SkuTier(d.Get("account_tier").(string))
+	if !storageAccountSupportsObjectReplication(accountKind, accountTier) {
+		return fmt.Errorf("`cross_tenant_replication_enabled` can only be enabled for Standard `StorageV2` or Premium `BlockBlobStorage` accounts, since Object Replication isn't supported for `account_kind` %q with `account_tier` %q", accountKind, accountTier)
+	}
+
+	return nil
+}
+
+// storageAccountSftpLocalUserDiff raises an error when SFTP is enabled but Local Users are disabled, since SFTP on
+// Azure Storage authenticates using Local Users and would otherwise silently reject all logins.
+func storageAccountSftpLocalUserDiff(ctx context.Context, d *pluginsdk.ResourceDiff, meta interface{}) error {
+	if !d.NewValueKnown("sftp_enabled") || !d.NewValueKnown("local_user_enabled") {
+		return nil
+	}
+
+	if d.Get("sftp_enabled").(bool) && !d.Get("local_user_enabled").(bool) {
+		return fmt.Errorf("`local_user_enabled` must be `true` when `sftp_enabled` is `true`, since SFTP authenticates using Local Users")
+	}
+
+	return nil
+}
+
+// storageAccountHnsRequiredFields are the fields which can only be enabled when `is_hns_enabled` is `true`.
+var storageAccountHnsRequiredFields = []string{
+	"nfsv3_enabled",
+	"sftp_enabled",
+}
+
+// storageAccountHnsRequiredFieldsDiff raises a single error listing every field which requires the Hierarchical Namespace
+// when `is_hns_enabled` is `false`, rather than each of these failing independently (and one at a time).
+func storageAccountHnsRequiredFieldsDiff(ctx context.Context, d *pluginsdk.ResourceDiff, meta interface{}) error {
+	if !d.NewValueKnown("is_hns_enabled") {
+		return nil
+	}
+
+	enabled := make(map[string]bool)
+	for _, field := range storageAccountHnsRequiredFields {
+		if d.NewValueKnown(field) {
+			enabled[field] = d.Get(field).(bool)
+		}
+	}
+
+	if fields := accountHnsRequiredFieldsViolations(d.Get("is_hns_enabled").(bool), enabled); len(fields) > 0 {
+		return fmt.Errorf("`is_hns_enabled` must be `true` when any of the following are enabled: %s", strings.Join(fields, ", "))
+	}
+
+	return nil
+}
+
+// accountHnsRequiredFieldsViolations returns the fields requiring the Hierarchical Namespace which are enabled
+// when `is_hns_enabled` is `false` - or an empty slice when there are none.
+func accountHnsRequiredFieldsViolations(hnsEnabled bool, enabled map[string]bool) []string {
+	fields := make([]string, 0)
+	if hnsEnabled {
+		return fields
+	}
+
+	for _, field := range storageAccountHnsRequiredFields {
+		if enabled[field] {
+			fields = append(fields, fmt.Sprintf("`%s`", field))
+		}
+	}
+
+	return fields
+}
+
+// storageAccountAllowedCopyScopeDiff raises an error when `allowed_copy_scope` is removed from an existing account, since
+// the API omits empty values from the payload and so the existing restriction would otherwise silently remain in place.
+func storageAccountAllowedCopyScopeDiff(ctx context.Context, d *pluginsdk.ResourceDiff, meta interface{}) error {
+	if d.Id() == "" || !d.HasChange("allowed_copy_scope") || !d.NewValueKnown("allowed_copy_scope") {
+		return nil
+	}
+
+	if oldScope, newScope := d.GetChange("allowed_copy_scope"); oldScope.(string) != "" && newScope.(string) == "" {
+		return fmt.Errorf("`allowed_copy_scope` cannot be removed once it has been set to %q - it can only be changed to another of: %s", oldScope.(string), strings.Join(storageaccounts.PossibleValuesForAllowedCopyScope(), " / "))
+	}
+
+	return nil
+}
+
+// storageAccountEdgeZoneReplicationDiff raises an error when a geo-redundant `account_replication_type` is used for a
+// Storage Account within an Edge Zone, since geo-replication isn't available within Edge Zones.
+func storageAccountEdgeZoneReplicationDiff(ctx context.Context, d *pluginsdk.ResourceDiff, meta interface{}) error {
+	if !d.NewValueKnown("edge_zone") || !d.NewValueKnown("account_replication_type") {
+		return nil
+	}
+
+	return validateAccountReplicationTypeForEdgeZone(d.Get("edge_zone").(string), d.Get("account_replication_type").(string))
+}
+
+func validateAccountReplicationTypeForEdgeZone(edgeZone, replicationType string) error {
+	if edgezones.Normalize(edgeZone) == "" {
+		return nil
+	}
+
+	for _, v := range []string{"GRS", "RAGRS", "GZRS", "RAGZRS"} {
+		if strings.EqualFold(replicationType, v) {
+			return fmt.Errorf("`account_replication_type` cannot be set to %q when `edge_zone` is specified, since geo-replication isn't available within Edge Zones - supported values are `LRS` and `ZRS`", replicationType)
+		}
+	}
+
+	return nil
+}
+
+// storageAccountSubnetServiceEndpointDiff ensures that each Subnet within `network_rules.virtual_network_subnet_ids`
+// exists and has the `Microsoft.Storage` Service Endpoint enabled, rather than the create/update failing once underway.
+// Since this requires retrieving each Subnet, this is only done when the `subnet_service_endpoint_validation_enabled`
+// feature is enabled.
+func storageAccountSubnetServiceEndpointDiff(ctx context.Context, d *pluginsdk.ResourceDiff, meta interface{}) error {
+	if !meta.(*clients.Client).Features.Storage.SubnetServiceEndpointValidationEnabled {
+		return nil
+	}
+	subnetsClient := meta.(*clients.Client).Network.Client.Subnets
+
+	config := d.GetRawConfig()
+	if config.IsNull() || !d.HasChange("network_rules") {
+		return nil
+	}
+	networkRules := config.GetAttr("network_rules")
+	if networkRules.IsNull() || !networkRules.IsWhollyKnown() {
+		return nil
+	}
+
+	subnetIds := make([]string, 0)
+	for it := networkRules.ElementIterator(); it.Next(); {
+		_, rule := it.Element()
+		if v := rule.GetAttr("virtual_network_subnet_ids"); !v.IsNull() {
+			for subnetIt := v.ElementIterator(); subnetIt.Next(); {
+				_, subnetId := subnetIt.Element()
+				subnetIds = append(subnetIds, subnetId.AsString())
+			}
+		}
+	}
+
+	for _, v := range subnetIds {
+		subnetId, err := commonids.ParseSubnetIDInsensitively(v)
+		if err != nil {
+			return err
+		}
+
+		resp, err := subnetsClient.Get(ctx, *subnetId, subnets.DefaultGetOperationOptions())
+		if err != nil {
+			if response.WasNotFound(resp.HttpResponse) {
+				return fmt.Errorf("the Subnet %q referenced in `network_rules.0.virtual_network_subnet_ids` was not found", subnetId.SubnetName)
+			}
+			return fmt.Errorf("retrieving %s: %+v", *subnetId, err)
+		}
+
+		if !subnetHasStorageServiceEndpoint(resp.Model) {
+			return fmt.Errorf("the Subnet %q referenced in `network_rules.0.virtual_network_subnet_ids` must have the `Microsoft.Storage` Service Endpoint enabled", subnetId.SubnetName)
+		}
+	}
+
+	return nil
+}
+
+func subnetHasStorageServiceEndpoint(input *subnets.Subnet) bool {
+	if input == nil || input.Properties == nil || input.Properties.ServiceEndpoints == nil {
+		return false
+	}
+
+	for _, endpoint := range *input.Properties.ServiceEndpoints {
+		// the global endpoint also permits access to Storage Accounts in the same region
+		service := pointer.From(endpoint.Service)
+		if strings.EqualFold(service, "Microsoft.Storage") || strings.EqualFold(service, "Microsoft.Storage.Global") {
+			return true
+		}
+	}
+
+	return false
+}
+
+// storageAccountDnsEndpointTypeBlobPropertiesDiff surfaces the `blob_properties` features which are incompatible with
+// partitioned DNS at plan time, rather than once the Blob Service Properties are being updated.
+func storageAccountDnsEndpointTypeBlobPropertiesDiff(ctx context.Context, d *pluginsdk.ResourceDiff, meta interface{}) error {
+	if !d.NewValueKnown("dns_endpoint_type") || !d.NewValueKnown("blob_properties") {
+		return nil
+	}
+
+	return validateAccountBlobPropertiesForDnsEndpointType(storageaccounts.DnsEndpointType(d.Get("dns_endpoint_type").(string)), d.Get("blob_properties").([]interface{}))
+}
+
+// storageAccountBlobPropertiesDependenciesDiff surfaces the dependencies between the `blob_properties` features at plan
+// time, rather than once the Blob Service Properties are being updated.
+func storageAccountBlobPropertiesDependenciesDiff(ctx context.Context, d *pluginsdk.ResourceDiff, meta interface{}) error {
+	if !d.NewValueKnown("blob_properties") {
+		return nil
+	}
+
+	return validateAccountBlobPropertiesDependencies(d.Get("blob_properties").([]interface{}))
+}
+
+// validateAccountBlobPropertiesDependencies validates the dependencies between the `blob_properties` features, returning
+// an error for the combinations which the API rejects.
+//
+// Ref: https://learn.microsoft.com/en-us/azure/storage/blobs/point-in-time-restore-overview#prerequisites-for-point-in-time-restore
+func validateAccountBlobPropertiesDependencies(blobPropertiesRaw []interface{}) error {
+	if len(blobPropertiesRaw) == 0 || blobPropertiesRaw[0] == nil {
+		return nil
+	}
+
+	blobProperties := blobPropertiesRaw[0].(map[string]interface{})
+	versioningEnabled, _ := blobProperties["versioning_enabled"].(bool)
+	changeFeedEnabled, _ := blobProperties["change_feed_enabled"].(bool)
+
+	if restorePolicy, ok := blobProperties["restore_policy"].([]interface{}); ok && len(restorePolicy) > 0 && restorePolicy[0] != nil {
+		missing := make([]string, 0)
+		if !versioningEnabled {
+			missing = append(missing, "`versioning_enabled`")
+		}
+		if !changeFeedEnabled {
+			missing = append(missing, "`change_feed_enabled`")
+		}
+		if len(missing) > 0 {
+			return fmt.Errorf("`restore_policy` requires both `versioning_enabled` and `change_feed_enabled` to be `true`, but %s must also be set to `true`", strings.Join(missing, " and "))
+		}
+	}
+
+	return nil
+}
+
+// storageAccountAzureDnsZoneIncompatibleBlobFeatures are the `blob_properties` features which the Storage service
+// doesn't support for accounts using partitioned DNS (where `dns_endpoint_type` is `AzureDnsZone`).
+//
+// TODO: This is a temporary limitation on Storage service. Remove these once the API supports these scenarios.
+// See https://github.com/hashicorp/terraform-provider-azurerm/pull/25450#discussion_r1542471667 for the context.
+var storageAccountAzureDnsZoneIncompatibleBlobFeatures = []struct {
+	field   string
+	enabled func(input map[string]interface{}) bool
+}{
+	{
+		// Otherwise, API returns: "Required feature Global Dns is disabled"
+		// This is confirmed with the SRP team, where they said:
+		// > restorePolicy feature is incompatible with partitioned DNS
+		field: "restore_policy",
+		enabled: func(input map[string]interface{}) bool {
+			v, ok := input["restore_policy"].([]interface{})
+			return ok && len(v) > 0 && v[0] != nil
+		},
+	},
+}
+
+func validateAccountBlobPropertiesForDnsEndpointType(dnsEndpointType storageaccounts.DnsEndpointType, blobPropertiesRaw []interface{}) error {
+	if dnsEndpointType != storageaccounts.DnsEndpointTypeAzureDnsZone || len(blobPropertiesRaw) == 0 || blobPropertiesRaw[0] == nil {
+		return nil
+	}
+
+	blobProperties := blobPropertiesRaw[0].(map[string]interface{})
+	for _, feature := range storageAccountAzureDnsZoneIncompatibleBlobFeatures {
+		if feature.enabled(blobProperties) {
+			return fmt.Errorf("`blob_properties.%s` can't be set when `dns_endpoint_type` is set to `%s`", feature.field, storageaccounts.DnsEndpointTypeAzureDnsZone)
+		}
+	}
+
+	return nil
+}
+
+// storageAccountEncryptionKeyTypeDiff surfaces the unsupported combinations of `account_kind` and the Queue/Table
+// encryption key types at plan time, rather than once the create/update is underway.
+func storageAccountEncryptionKeyTypeDiff(ctx context.Context, d *pluginsdk.ResourceDiff, meta interface{}) error {
+	if !d.NewValueKnown("account_kind") || !d.NewValueKnown("queue_encryption_key_type") || !d.NewValueKnown("table_encryption_key_type") {
+		return nil
+	}
+
+	accountKind := storageaccounts.Kind(d.Get("account_kind").(string))
+	queueEncryptionKeyType := storageaccounts.KeyType(d.Get("queue_encryption_key_type").(string))
+	tableEncryptionKeyType := storageaccounts.KeyType(d.Get("table_encryption_key_type").(string))
+
+	return validateAccountEncryptionKeyTypes(accountKind, queueEncryptionKeyType, tableEncryptionKeyType)
+}
+
+// storageAccountCustomerManagedKeyIdentityDiff ensures the User Assigned Identity used to access the Customer Managed Key
+// is assigned to the Storage Account, since otherwise the API only returns a vague error once the create/update is underway.
+func storageAccountCustomerManagedKeyIdentityDiff(ctx context.Context, d *pluginsdk.ResourceDiff, meta interface{}) error {
+	if len(d.Get("customer_managed_key").([]interface{})) == 0 {
+		return nil
+	}
+
+	// the identity may be created within the same apply, in which case we can't check this until then
+	if !d.NewValueKnown("customer_managed_key.0.user_assigned_identity_id") {
+		return nil
+	}
+	if config := d.GetRawConfig(); !config.IsNull() && !config.GetAttr("identity").IsWhollyKnown() {
+		return nil
+	}
+
+	userAssignedIdentityId := d.Get("customer_managed_key.0.user_assigned_identity_id").(string)
+	if userAssignedIdentityId == "" {
+		return nil
+	}
+
+	identityIds := make([]interface{}, 0)
+	if v := d.Get("identity").([]interface{}); len(v) > 0 && v[0] != nil {
+		if raw, ok := v[0].(map[string]interface{})["identity_ids"].(*pluginsdk.Set); ok {
+			identityIds = raw.List()
+		}
+	}
+
+	for _, identityId := range identityIds {
+		if strings.EqualFold(identityId.(string), userAssignedIdentityId) {
+			return nil
+		}
+	}
+
+	return fmt.Errorf("the User Assigned Identity %q specified in `customer_managed_key.0.user_assigned_identity_id` must also be assigned to the Storage Account within the `identity` block's `identity_ids`", userAssignedIdentityId)
+}
+
 func expandAccountCustomDomain(input []interface{}) *storageaccounts.CustomDomain {
 	if len(input) == 0 {
 		return &storageaccounts.CustomDomain{
@@ -2360,19 +2924,37 @@ func flattenAccountCustomDomain(input *storageaccounts.CustomDomain) []interface
 	return output
 }
 
-func expandAccountCustomerManagedKey(ctx context.Context, keyVaultClient *keyVaultClient.Client, subscriptionId string, input []interface{}, accountTier storageaccounts.SkuTier, accountKind storageaccounts.Kind, expandedIdentity identity.LegacySystemAndUserAssignedMap, queueEncryptionKeyType, tableEncryptionKeyType storageaccounts.KeyType) (*storageaccounts.Encryption, error) {
+func validateAccountEncryptionKeyTypes(accountKind storageaccounts.Kind, queueEncryptionKeyType, tableEncryptionKeyType storageaccounts.KeyType) error {
 	if accountKind == storageaccounts.KindStorage {
 		if queueEncryptionKeyType == storageaccounts.KeyTypeAccount {
-			return nil, fmt.Errorf("`queue_encryption_key_type = %q` cannot be used with account kind `%q`", string(storageaccounts.KeyTypeAccount), string(storageaccounts.KindStorage))
+			return fmt.Errorf("`queue_encryption_key_type = %q` cannot be used with account kind `%q`", string(storageaccounts.KeyTypeAccount), string(storageaccounts.KindStorage))
 		}
 		if tableEncryptionKeyType == storageaccounts.KeyTypeAccount {
-			return nil, fmt.Errorf("`table_encryption_key_type = %q` cannot be used with account kind `%q`", string(storageaccounts.KeyTypeAccount), string(storageaccounts.KindStorage))
+			return fmt.Errorf("`table_encryption_key_type = %q` cannot be used with account kind `%q`", string(storageaccounts.KeyTypeAccount), string(storageaccounts.KindStorage))
 		}
 	}
+
+	return nil
+}
+
+func expandAccountCustomerManagedKey(ctx context.Context, keyVaultClient *keyVaultClient.Client, subscriptionId string, input []interface{}, accountTier storageaccounts.SkuTier, accountKind storageaccounts.Kind, expandedIdentity identity.LegacySystemAndUserAssignedMap, queueEncryptionKeyType, tableEncryptionKeyType storageaccounts.KeyType) (*storageaccounts.Encryption, error) {
+	if err := validateAccountEncryptionKeyTypes(accountKind, queueEncryptionKeyType, tableEncryptionKeyType); err != nil {
+		return nil, err
+	}
 	if len(input) == 0 {
+		// when the `customer_managed_key` block is removed the account reverts to using Microsoft managed keys, the Key
+		// Vault properties and Identity are ignored by the API once the Key Source is `Microsoft.Storage` so are omitted
 		return &storageaccounts.Encryption{
 			KeySource: pointer.To(storageaccounts.KeySourceMicrosoftPointStorage),
 			Services: &storageaccounts.EncryptionServices{
+				Blob: &storageaccounts.EncryptionService{
+					Enabled: pointer.To(true),
+					KeyType: pointer.To(storageaccounts.KeyTypeAccount),
+				},
+				File: &storageaccounts.EncryptionService{
+					Enabled: pointer.To(true),
+					KeyType: pointer.To(storageaccounts.KeyTypeAccount),
+				},
 				Queue: &storageaccounts.EncryptionService{
 					KeyType: pointer.To(queueEncryptionKeyType),
 				},
@@ -2393,6 +2975,8 @@ func expandAccountCustomerManagedKey(ctx context.Context, keyVaultClient *keyVau
 
 	v := input[0].(map[string]interface{})
 
+	federatedIdentityClientId := v["federated_identity_client_id"].(string)
+
 	var keyName, keyVersion, keyVaultURI *string
 	if keyVaultKeyId, ok := v["key_vault_key_id"]; ok && keyVaultKeyId != "" {
 		keyId, err := keyVaultParse.ParseOptionallyVersionedNestedItemID(keyVaultKeyId.(string))
@@ -2400,38 +2984,12 @@ func expandAccountCustomerManagedKey(ctx context.Context, keyVaultClient *keyVau
 			return nil, err
 		}
 
-		subscriptionResourceId := commonids.NewSubscriptionID(subscriptionId)
-		keyVaultIdRaw, err := keyVaultClient.KeyVaultIDFromBaseUrl(ctx, subscriptionResourceId, keyId.KeyVaultBaseUrl)
-		if err != nil {
-			return nil, err
-		}
-		if keyVaultIdRaw == nil {
-			return nil, fmt.Errorf("unable to find the Resource Manager ID for the Key Vault URI %q in %s", keyId.KeyVaultBaseUrl, subscriptionResourceId)
-		}
-		keyVaultId, err := commonids.ParseKeyVaultID(*keyVaultIdRaw)
-		if err != nil {
-			return nil, err
-		}
-
-		vaultsClient := keyVaultClient.VaultsClient
-		keyVault, err := vaultsClient.Get(ctx, *keyVaultId)
-		if err != nil {
-			return nil, fmt.Errorf("retrieving %s: %+v", *keyVaultId, err)
-		}
-
-		softDeleteEnabled := false
-		purgeProtectionEnabled := false
-		if model := keyVault.Model; model != nil {
-			if esd := model.Properties.EnableSoftDelete; esd != nil {
-				softDeleteEnabled = *esd
-			}
-			if epp := model.Properties.EnablePurgeProtection; epp != nil {
-				purgeProtectionEnabled = *epp
+		// when a Federated Identity is specified the Key Vault lives within another tenant, so it can't be looked up (and validated) here
+		if federatedIdentityClientId == "" {
+			if err := validateAccountCustomerManagedKeyVault(ctx, keyVaultClient, subscriptionId, keyId.KeyVaultBaseUrl); err != nil {
+				return nil, err
 			}
 		}
-		if !softDeleteEnabled || !purgeProtectionEnabled {
-			return nil, fmt.Errorf("%s must be configured for both Purge Protection and Soft Delete", *keyVaultId)
-		}
 
 		keyName = pointer.To(keyId.Name)
 		keyVersion = pointer.To(keyId.Version)
@@ -2478,23 +3036,69 @@ func expandAccountCustomerManagedKey(ctx context.Context, keyVaultClient *keyVau
 		},
 	}
 
+	if federatedIdentityClientId != "" {
+		encryption.Identity.FederatedIdentityClientId = pointer.To(federatedIdentityClientId)
+	}
+
 	return encryption, nil
 }
 
+// validateAccountCustomerManagedKeyVault ensures the Key Vault containing the Customer Managed Key exists within the
+// Subscription and has both Soft Delete and Purge Protection enabled, which is required for use as a Customer Managed Key.
+func validateAccountCustomerManagedKeyVault(ctx context.Context, keyVaultClient *keyVaultClient.Client, subscriptionId string, keyVaultBaseUrl string) error {
+	subscriptionResourceId := commonids.NewSubscriptionID(subscriptionId)
+	keyVaultIdRaw, err := keyVaultClient.KeyVaultIDFromBaseUrl(ctx, subscriptionResourceId, keyVaultBaseUrl)
+	if err != nil {
+		return err
+	}
+	if keyVaultIdRaw == nil {
+		return fmt.Errorf("unable to find the Resource Manager ID for the Key Vault URI %q in %s", keyVaultBaseUrl, subscriptionResourceId)
+	}
+	keyVaultId, err := commonids.ParseKeyVaultID(*keyVaultIdRaw)
+	if err != nil {
+		return err
+	}
+
+	vaultsClient := keyVaultClient.VaultsClient
+	keyVault, err := vaultsClient.Get(ctx, *keyVaultId)
+	if err != nil {
+		return fmt.Errorf("retrieving %s: %+v", *keyVaultId, err)
+	}
+
+	softDeleteEnabled := false
+	purgeProtectionEnabled := false
+	if model := keyVault.Model; model != nil {
+		if esd := model.Properties.EnableSoftDelete; esd != nil {
+			softDeleteEnabled = *esd
+		}
+		if epp := model.Properties.EnablePurgeProtection; epp != nil {
+			purgeProtectionEnabled = *epp
+		}
+	}
+	if !softDeleteEnabled || !purgeProtectionEnabled {
+		return fmt.Errorf("%s must be configured for both Purge Protection and Soft Delete", *keyVaultId)
+	}
+
+	return nil
+}
+
 func flattenAccountCustomerManagedKey(input *storageaccounts.Encryption, env environments.Environment) []interface{} {
 	output := make([]interface{}, 0)
 
 	if input != nil && input.KeySource != nil && *input.KeySource == storageaccounts.KeySourceMicrosoftPointKeyvault {
 		userAssignedIdentityId := ""
+		federatedIdentityClientId := ""
 		if props := input.Identity; props != nil {
 			userAssignedIdentityId = pointer.From(props.UserAssignedIdentity)
+			federatedIdentityClientId = pointer.From(props.FederatedIdentityClientId)
 		}
 
 		customerManagedKey := flattenCustomerManagedKey(input.Keyvaultproperties, env.KeyVault, env.ManagedHSM)
 		output = append(output, map[string]interface{}{
-			"key_vault_key_id":          customerManagedKey.keyVaultKeyUri,
-			"managed_hsm_key_id":        customerManagedKey.managedHsmKeyUri,
-			"user_assigned_identity_id": userAssignedIdentityId,
+			"key_vault_key_id":             customerManagedKey.keyVaultKeyUri,
+			"managed_hsm_key_id":           customerManagedKey.managedHsmKeyUri,
+			"user_assigned_identity_id":    userAssignedIdentityId,
+			"federated_identity_client_id": federatedIdentityClientId,
 		})
 	}
 
@@ -2531,6 +3135,17 @@ func flattenAccountImmutabilityPolicy(input *storageaccounts.ImmutableStorageAcc
 	}
 }
 
+// accountHasImmutabilityPolicy returns whether immutability is configured at the account level - which is distinct
+// from `immutability_policy`, since immutability can be enabled on the account without an account-level policy.
+// Immutability Policies configured only on individual Containers aren't included.
+func accountHasImmutabilityPolicy(input *storageaccounts.ImmutableStorageAccount) bool {
+	if input == nil {
+		return false
+	}
+
+	return pointer.From(input.Enabled) || input.ImmutabilityPolicy != nil
+}
+
 func expandAccountActiveDirectoryProperties(input []interface{}) *storageaccounts.ActiveDirectoryProperties {
 	if len(input) == 0 {
 		return nil
@@ -2587,6 +3202,8 @@ func expandAccountAzureFilesAuthentication(input []interface{}) (*storageaccount
 		output.DirectoryServiceOptions == storageaccounts.DirectoryServiceOptionsAADKERB {
 		ad := expandAccountActiveDirectoryProperties(v["active_directory"].([]interface{}))
 
+		// the `active_directory` block is optional for `AADDS` and `AADKERB` (e.g. Microsoft Entra Kerberos for cloud-only
+		// identities only needs the `default_share_level_permission`), the domain details are only required for `AD`
 		if output.DirectoryServiceOptions == storageaccounts.DirectoryServiceOptionsAD {
 			if ad == nil {
 				return nil, fmt.Errorf("`active_directory` is required when `directory_type` is `AD`")
@@ -2638,9 +3255,16 @@ func expandAccountRoutingPreference(input []interface{}) *storageaccounts.Routin
 	}
 }
 
-func flattenAccountRoutingPreference(input *storageaccounts.RoutingPreference) []interface{} {
+// flattenAccountRoutingPreference omits the `routing` block when it matches the service defaults (Microsoft routing
+// without publishing any route-specific endpoints) and it isn't already in the state, so that it doesn't show up
+// for Storage Accounts which never configured it
+func flattenAccountRoutingPreference(input *storageaccounts.RoutingPreference, existing []interface{}) []interface{} {
 	output := make([]interface{}, 0)
 
+	if input != nil && len(existing) == 0 && accountRoutingPreferenceIsDefault(*input) {
+		return output
+	}
+
 	if input != nil {
 		routingChoice := ""
 		if input.RoutingChoice != nil {
@@ -2657,6 +3281,15 @@ func flattenAccountRoutingPreference(input *storageaccounts.RoutingPreference) [
 	return output
 }
 
+func accountRoutingPreferenceIsDefault(input storageaccounts.RoutingPreference) bool {
+	routingChoice := storageaccounts.RoutingChoiceMicrosoftRouting
+	if input.RoutingChoice != nil && *input.RoutingChoice != "" {
+		routingChoice = *input.RoutingChoice
+	}
+
+	return routingChoice == storageaccounts.RoutingChoiceMicrosoftRouting && !pointer.From(input.PublishInternetEndpoints) && !pointer.From(input.PublishMicrosoftEndpoints)
+}
+
 func expandAccountBlobServiceProperties(kind storageaccounts.Kind, input []interface{}) (*blobservice.BlobServiceProperties, error) {
 	props := blobservice.BlobServicePropertiesProperties{
 		Cors: &blobservice.CorsRules{
@@ -2717,7 +3350,11 @@ func expandAccountBlobServiceProperties(kind storageaccounts.Kind, input []inter
 			props.ChangeFeed = &blobservice.ChangeFeed{
 				Enabled: pointer.To(changeFeedEnabled),
 			}
-			if changeFeedRetentionInDays != 0 {
+			if changeFeedRetentionInDays != 0 && !changeFeedEnabled {
+				return nil, fmt.Errorf("`change_feed_retention_in_days` can only be set when `change_feed_enabled` is `true`")
+			}
+			// a retention of `-1` (or omitting it) means the change feed is retained indefinitely
+			if changeFeedRetentionInDays > 0 {
 				props.ChangeFeed.RetentionInDays = pointer.To(int64(changeFeedRetentionInDays))
 			}
 			props.RestorePolicy = expandAccountBlobPropertiesRestorePolicy(restorePolicyRaw)
@@ -2740,15 +3377,8 @@ func expandAccountBlobServiceProperties(kind storageaccounts.Kind, input []inter
 			}
 		}
 
-		// Sanity check for the prerequisites of restore_policy
-		// Ref: https://learn.microsoft.com/en-us/azure/storage/blobs/point-in-time-restore-overview#prerequisites-for-point-in-time-restore
-		if p := props.RestorePolicy; p != nil && p.Enabled {
-			if props.ChangeFeed == nil || props.ChangeFeed.Enabled == nil || !*props.ChangeFeed.Enabled {
-				return nil, fmt.Errorf("`change_feed_enabled` must be `true` when `restore_policy` is set")
-			}
-			if props.IsVersioningEnabled == nil || !*props.IsVersioningEnabled {
-				return nil, fmt.Errorf("`versioning_enabled` must be `true` when `restore_policy` is set")
-			}
+		if err := validateAccountBlobPropertiesDependencies(input); err != nil {
+			return nil, err
 		}
 	}
 
@@ -1149,6 +1149,7 @@ func TestAccStorageAccount_sharedKeyAccess(t *testing.T) {
 				check.That(data.ResourceName).Key("tags.%").HasValue("1"),
 				check.That(data.ResourceName).Key("tags.environment").HasValue("production"),
 				check.That(data.ResourceName).Key("shared_access_key_enabled").HasValue("false"),
+				check.That(data.ResourceName).Key("primary_connection_string").MatchesRegex(regexp.MustCompile("^DefaultEndpointsProtocol=https;AccountName=[a-z0-9]+;EndpointSuffix=.+$")),
 			),
 		},
 		data.ImportStep(),
@@ -1251,6 +1252,28 @@ func TestAccStorageAccount_encryptionKeyType_Service(t *testing.T) {
 	})
 }
 
+func TestAccStorageAccount_encryptionKeyType_ServiceToAccount(t *testing.T) {
+	data := acceptance.BuildTestData(t, "azurerm_storage_account", "test")
+	r := StorageAccountResource{}
+
+	data.ResourceTest(t, r, []acceptance.TestStep{
+		{
+			Config: r.encryptionKeyType(data, "Service"),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+			),
+		},
+		data.ImportStep(),
+		{
+			Config: r.encryptionKeyType(data, "Account"),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+			),
+		},
+		data.ImportStep(),
+	})
+}
+
 func TestAccStorageAccount_infrastructureEncryptionStorageV2_Enabled(t *testing.T) {
 	data := acceptance.BuildTestData(t, "azurerm_storage_account", "test")
 	r := StorageAccountResource{}
@@ -1343,6 +1366,18 @@ func TestAccStorageAccount_customerManagedKey(t *testing.T) {
 	})
 }
 
+func TestAccStorageAccount_customerManagedKeyIdentityNotAssigned(t *testing.T) {
+	data := acceptance.BuildTestData(t, "azurerm_storage_account", "test")
+	r := StorageAccountResource{}
+
+	data.ResourceTest(t, r, []acceptance.TestStep{
+		{
+			Config:      r.customerManagedKeyIdentityNotAssigned(data),
+			ExpectError: regexp.MustCompile("must also be assigned to the Storage Account within the `identity` block's `identity_ids`"),
+		},
+	})
+}
+
 func TestAccStorageAccount_customerManagedKeyForSUAI(t *testing.T) {
 	data := acceptance.BuildTestData(t, "azurerm_storage_account", "test")
 	r := StorageAccountResource{}
@@ -1395,6 +1430,29 @@ func TestAccStorageAccount_customerManagedKeyUpdated(t *testing.T) {
 	})
 }
 
+func TestAccStorageAccount_customerManagedKeyRemoved(t *testing.T) {
+	data := acceptance.BuildTestData(t, "azurerm_storage_account", "test")
+	r := StorageAccountResource{}
+
+	data.ResourceTest(t, r, []acceptance.TestStep{
+		{
+			Config: r.customerManagedKey(data),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+			),
+		},
+		data.ImportStep(),
+		{
+			Config: r.customerManagedKeyRemoved(data),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+				check.That(data.ResourceName).Key("customer_managed_key.#").HasValue("0"),
+			),
+		},
+		data.ImportStep(),
+	})
+}
+
 func TestAccStorageAccount_customerManagedKeyRemoteKeyVault(t *testing.T) {
 	data := acceptance.BuildTestData(t, "azurerm_storage_account", "test")
 	r := StorageAccountResource{}
@@ -1410,6 +1468,30 @@ func TestAccStorageAccount_customerManagedKeyRemoteKeyVault(t *testing.T) {
 	})
 }
 
+func TestAccStorageAccount_customerManagedKeyWithFederatedIdentity(t *testing.T) {
+	// Multiple tenants are needed for this test
+	altTenantId := os.Getenv("ARM_TENANT_ID_ALT")
+	subscriptionIdAltTenant := os.Getenv("ARM_SUBSCRIPTION_ID_ALT_TENANT")
+
+	if altTenantId == "" || subscriptionIdAltTenant == "" {
+		t.Skip("One of ARM_TENANT_ID_ALT, ARM_SUBSCRIPTION_ID_ALT_TENANT are not specified")
+	}
+
+	data := acceptance.BuildTestData(t, "azurerm_storage_account", "test")
+	r := StorageAccountResource{}
+
+	data.ResourceTest(t, r, []acceptance.TestStep{
+		{
+			Config: r.customerManagedKeyWithFederatedIdentity(data, altTenantId, subscriptionIdAltTenant),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+				check.That(data.ResourceName).Key("customer_managed_key.0.federated_identity_client_id").Exists(),
+			),
+		},
+		data.ImportStep(),
+	})
+}
+
 func TestAccStorageAccount_updateToUsingIdentityAndCustomerManagedKey(t *testing.T) {
 	data := acceptance.BuildTestData(t, "azurerm_storage_account", "test")
 	r := StorageAccountResource{}
@@ -1582,6 +1664,10 @@ func TestAccStorageAccount_allowedCopyScope(t *testing.T) {
 			),
 		},
 		data.ImportStep(),
+		{
+			Config:      r.basic(data),
+			ExpectError: regexp.MustCompile("`allowed_copy_scope` cannot be removed once it has been set"),
+		},
 	})
 }
 
@@ -1608,6 +1694,18 @@ func TestAccStorageAccount_isSftpEnabled(t *testing.T) {
 	})
 }
 
+func TestAccStorageAccount_sftpEnabledWithoutLocalUser(t *testing.T) {
+	data := acceptance.BuildTestData(t, "azurerm_storage_account", "test")
+	r := StorageAccountResource{}
+
+	data.ResourceTest(t, r, []acceptance.TestStep{
+		{
+			Config:      r.sftpEnabledWithoutLocalUser(data),
+			ExpectError: regexp.MustCompile("`local_user_enabled` must be `true` when `sftp_enabled` is `true`"),
+		},
+	})
+}
+
 func TestAccStorageAccount_isLocalUserEnabled(t *testing.T) {
 	data := acceptance.BuildTestData(t, "azurerm_storage_account", "test")
 	r := StorageAccountResource{}
@@ -1679,6 +1777,42 @@ func TestAccStorageAccount_invalidAccountKindForAccessTier(t *testing.T) {
 	})
 }
 
+func TestAccStorageAccount_blockBlobStorageWithAccessTier(t *testing.T) {
+	data := acceptance.BuildTestData(t, "azurerm_storage_account", "test")
+	r := StorageAccountResource{}
+
+	data.ResourceTest(t, r, []acceptance.TestStep{
+		{
+			Config:      r.blockBlobStorageWithAccessTier(data),
+			ExpectError: regexp.MustCompile("`access_tier` is not supported for Premium accounts where `account_kind` is set to `BlockBlobStorage`"),
+		},
+	})
+}
+
+func TestAccStorageAccount_crossTenantReplicationUnsupported(t *testing.T) {
+	data := acceptance.BuildTestData(t, "azurerm_storage_account", "test")
+	r := StorageAccountResource{}
+
+	data.ResourceTest(t, r, []acceptance.TestStep{
+		{
+			Config:      r.crossTenantReplicationFileStorage(data),
+			ExpectError: regexp.MustCompile("`cross_tenant_replication_enabled` can only be enabled for Standard `StorageV2` or Premium `BlockBlobStorage` accounts"),
+		},
+	})
+}
+
+func TestAccStorageAccount_encryptionKeyTypeAccountStorageV1(t *testing.T) {
+	data := acceptance.BuildTestData(t, "azurerm_storage_account", "test")
+	r := StorageAccountResource{}
+
+	data.ResourceTest(t, r, []acceptance.TestStep{
+		{
+			Config:      r.encryptionKeyTypeStorageV1(data),
+			ExpectError: regexp.MustCompile("`queue_encryption_key_type = \"Account\"` cannot be used with account kind `\"Storage\"`"),
+		},
+	})
+}
+
 func TestAccStorageAccount_StorageV1_blobProperties(t *testing.T) {
 	data := acceptance.BuildTestData(t, "azurerm_storage_account", "test")
 	r := StorageAccountResource{}
@@ -4261,6 +4395,70 @@ resource "azurerm_storage_account" "test" {
 `, r.cmkTemplate(data), data.RandomString)
 }
 
+func (r StorageAccountResource) customerManagedKeyRemoved(data acceptance.TestData) string {
+	return fmt.Sprintf(`
+%s
+
+resource "azurerm_storage_account" "test" {
+  name                     = "unlikely23exst2acct%s"
+  resource_group_name      = azurerm_resource_group.test.name
+  location                 = azurerm_resource_group.test.location
+  account_tier             = "Standard"
+  account_replication_type = "LRS"
+  account_kind             = "StorageV2"
+  identity {
+    type = "UserAssigned"
+    identity_ids = [
+      azurerm_user_assigned_identity.test.id,
+    ]
+  }
+
+  infrastructure_encryption_enabled = true
+  table_encryption_key_type         = "Account"
+  queue_encryption_key_type         = "Account"
+
+  tags = {
+    environment = "production"
+  }
+}
+`, r.cmkTemplate(data), data.RandomString)
+}
+
+func (r StorageAccountResource) customerManagedKeyIdentityNotAssigned(data acceptance.TestData) string {
+	return fmt.Sprintf(`
+provider "azurerm" {
+  features {}
+}
+
+data "azurerm_client_config" "current" {}
+
+resource "azurerm_resource_group" "test" {
+  name     = "acctestRG-storage-%[1]d"
+  location = "%[2]s"
+}
+
+resource "azurerm_storage_account" "test" {
+  name                     = "unlikely23exst2acct%[3]s"
+  resource_group_name      = azurerm_resource_group.test.name
+  location                 = azurerm_resource_group.test.location
+  account_tier             = "Standard"
+  account_replication_type = "LRS"
+  account_kind             = "StorageV2"
+  identity {
+    type = "UserAssigned"
+    identity_ids = [
+      "/subscriptions/${data.azurerm_client_config.current.subscription_id}/resourceGroups/acctestRG-storage-%[1]d/providers/Microsoft.ManagedIdentity/userAssignedIdentities/acctestmi1%[3]s",
+    ]
+  }
+
+  customer_managed_key {
+    key_vault_key_id          = "https://acctestkv%[3]s.vault.azure.net/keys/acctestkey"
+    user_assigned_identity_id = "/subscriptions/${data.azurerm_client_config.current.subscription_id}/resourceGroups/acctestRG-storage-%[1]d/providers/Microsoft.ManagedIdentity/userAssignedIdentities/acctestmi2%[3]s"
+  }
+}
+`, data.RandomInteger, data.Locations.Primary, data.RandomString)
+}
+
 func (r StorageAccountResource) customerManagedKeyUpdate(data acceptance.TestData) string {
 	return fmt.Sprintf(`
 %s
@@ -4427,6 +4625,141 @@ resource "azurerm_storage_account" "test" {
 `, r.hsmKeyTemplate(data), data.RandomString)
 }
 
+func (r StorageAccountResource) customerManagedKeyWithFederatedIdentity(data acceptance.TestData, altTenantId, subscriptionIdAltTenant string) string {
+	return fmt.Sprintf(`
+provider "azurerm" {
+  features {}
+}
+
+provider "azurerm-alt" {
+  tenant_id       = "%[1]s"
+  subscription_id = "%[2]s"
+
+  features {
+    key_vault {
+      purge_soft_delete_on_destroy       = false
+      purge_soft_deleted_keys_on_destroy = false
+    }
+  }
+}
+
+provider "azuread" {}
+
+provider "azuread" {
+  alias     = "alt"
+  tenant_id = "%[1]s"
+}
+
+data "azurerm_client_config" "current" {}
+
+data "azurerm_client_config" "remote" {
+  provider = azurerm-alt
+}
+
+data "azuread_client_config" "current" {}
+
+data "azuread_client_config" "remote" {
+  provider = azuread.alt
+}
+
+resource "azurerm_resource_group" "test" {
+  name     = "acctestRG-storage-%[3]d"
+  location = "%[4]s"
+}
+
+resource "azuread_application" "test" {
+  display_name     = "acctestapp-%[5]s"
+  sign_in_audience = "AzureADMultipleOrgs"
+  owners           = [data.azuread_client_config.current.object_id]
+}
+
+resource "azurerm_user_assigned_identity" "test" {
+  name                = "acctestmi-%[5]s"
+  resource_group_name = azurerm_resource_group.test.name
+  location            = azurerm_resource_group.test.location
+}
+
+resource "azuread_application_federated_identity_credential" "test" {
+  application_object_id = azuread_application.test.object_id
+  display_name          = "acctestcred-%[5]s"
+  description           = "Federated Identity Credential for CMK"
+  audiences             = ["api://AzureADTokenExchange"]
+  issuer                = "https://login.microsoftonline.com/${data.azurerm_client_config.current.tenant_id}/v2.0"
+  subject               = azurerm_user_assigned_identity.test.principal_id
+}
+
+resource "azurerm_resource_group" "remotetest" {
+  provider = azurerm-alt
+  name     = "acctestRG-alt-%[3]d"
+  location = "%[4]s"
+}
+
+resource "azuread_service_principal" "remotetest" {
+  provider       = azuread.alt
+  owners         = [data.azuread_client_config.remote.object_id]
+  application_id = azuread_application.test.application_id
+}
+
+resource "azurerm_key_vault" "remotetest" {
+  provider = azurerm-alt
+
+  name                     = "acctestkv%[5]s"
+  location                 = azurerm_resource_group.remotetest.location
+  resource_group_name      = azurerm_resource_group.remotetest.name
+  tenant_id                = data.azurerm_client_config.remote.tenant_id
+  sku_name                 = "standard"
+  purge_protection_enabled = true
+
+  access_policy {
+    tenant_id = data.azurerm_client_config.remote.tenant_id
+    object_id = data.azurerm_client_config.remote.object_id
+
+    key_permissions    = ["Get", "Create", "Delete", "List", "Restore", "Recover", "UnwrapKey", "WrapKey", "Purge", "Encrypt", "Decrypt", "Sign", "Verify", "GetRotationPolicy"]
+    secret_permissions = ["Get"]
+  }
+
+  access_policy {
+    tenant_id = data.azurerm_client_config.remote.tenant_id
+    object_id = azuread_service_principal.remotetest.object_id
+
+    key_permissions = [
+      "Get", "List", "UnwrapKey", "WrapKey",
+    ]
+  }
+}
+
+resource "azurerm_key_vault_key" "remotetest" {
+  provider = azurerm-alt
+
+  name         = "remote"
+  key_vault_id = azurerm_key_vault.remotetest.id
+  key_type     = "RSA"
+  key_size     = 2048
+  key_opts     = ["decrypt", "encrypt", "sign", "unwrapKey", "verify", "wrapKey"]
+}
+
+resource "azurerm_storage_account" "test" {
+  name                     = "unlikely23exst2acct%[5]s"
+  resource_group_name      = azurerm_resource_group.test.name
+  location                 = azurerm_resource_group.test.location
+  account_tier             = "Standard"
+  account_replication_type = "LRS"
+  account_kind             = "StorageV2"
+
+  identity {
+    type         = "UserAssigned"
+    identity_ids = [azurerm_user_assigned_identity.test.id]
+  }
+
+  customer_managed_key {
+    key_vault_key_id             = azurerm_key_vault_key.remotetest.versionless_id
+    user_assigned_identity_id    = azurerm_user_assigned_identity.test.id
+    federated_identity_client_id = azuread_application.test.application_id
+  }
+}
+`, altTenantId, subscriptionIdAltTenant, data.RandomInteger, data.Locations.Primary, data.RandomString)
+}
+
 func (r StorageAccountResource) customerManagedKeyRemoteKeyVault(data acceptance.TestData) string {
 	clientData := data.Client()
 	return fmt.Sprintf(`
@@ -4862,10 +5195,36 @@ resource "azurerm_storage_account" "test" {
   account_tier             = "Standard"
   account_replication_type = "LRS"
   is_hns_enabled           = true
-  sftp_enabled             = true
+  sftp_enabled             = %t
   local_user_enabled       = %t
 }
-`, data.RandomInteger, data.Locations.Primary, data.RandomString, v)
+`, data.RandomInteger, data.Locations.Primary, data.RandomString, v, v)
+}
+
+func (r StorageAccountResource) sftpEnabledWithoutLocalUser(data acceptance.TestData) string {
+	return fmt.Sprintf(`
+provider "azurerm" {
+  features {}
+}
+
+resource "azurerm_resource_group" "test" {
+  name     = "acctestRG-storage-%d"
+  location = "%s"
+}
+
+resource "azurerm_storage_account" "test" {
+  name                = "unlikely23exst2acct%s"
+  resource_group_name = azurerm_resource_group.test.name
+
+  location                 = azurerm_resource_group.test.location
+  account_kind             = "StorageV2"
+  account_tier             = "Standard"
+  account_replication_type = "LRS"
+  is_hns_enabled           = true
+  sftp_enabled             = true
+  local_user_enabled       = false
+}
+`, data.RandomInteger, data.Locations.Primary, data.RandomString)
 }
 
 func (r StorageAccountResource) blobPropertiesStorageKindNotSupportLastAccessTimeEnabled(data acceptance.TestData) string {
@@ -4940,6 +5299,76 @@ resource "azurerm_storage_account" "test" {
 `, data.RandomInteger, data.Locations.Primary, data.RandomString)
 }
 
+func (r StorageAccountResource) blockBlobStorageWithAccessTier(data acceptance.TestData) string {
+	return fmt.Sprintf(`
+provider "azurerm" {
+  features {}
+}
+
+resource "azurerm_resource_group" "test" {
+  name     = "acctestAzureRMSA-%d"
+  location = "%s"
+}
+
+resource "azurerm_storage_account" "test" {
+  name                     = "unlikely23exst2acct%s"
+  location                 = azurerm_resource_group.test.location
+  resource_group_name      = azurerm_resource_group.test.name
+  account_kind             = "BlockBlobStorage"
+  account_tier             = "Premium"
+  access_tier              = "Hot"
+  account_replication_type = "LRS"
+}
+`, data.RandomInteger, data.Locations.Primary, data.RandomString)
+}
+
+func (r StorageAccountResource) crossTenantReplicationFileStorage(data acceptance.TestData) string {
+	return fmt.Sprintf(`
+provider "azurerm" {
+  features {}
+}
+
+resource "azurerm_resource_group" "test" {
+  name     = "acctestAzureRMSA-%d"
+  location = "%s"
+}
+
+resource "azurerm_storage_account" "test" {
+  name                             = "unlikely23exst2acct%s"
+  location                         = azurerm_resource_group.test.location
+  resource_group_name              = azurerm_resource_group.test.name
+  account_kind                     = "FileStorage"
+  account_tier                     = "Premium"
+  account_replication_type         = "LRS"
+  cross_tenant_replication_enabled = true
+}
+`, data.RandomInteger, data.Locations.Primary, data.RandomString)
+}
+
+func (r StorageAccountResource) encryptionKeyTypeStorageV1(data acceptance.TestData) string {
+	return fmt.Sprintf(`
+provider "azurerm" {
+  features {}
+}
+
+resource "azurerm_resource_group" "test" {
+  name     = "acctestRG-storage-%d"
+  location = "%s"
+}
+
+resource "azurerm_storage_account" "test" {
+  name                      = "unlikely23exst2acct%s"
+  resource_group_name       = azurerm_resource_group.test.name
+  location                  = azurerm_resource_group.test.location
+  account_kind              = "Storage"
+  account_tier              = "Standard"
+  account_replication_type  = "LRS"
+  table_encryption_key_type = "Account"
+  queue_encryption_key_type = "Account"
+}
+`, data.RandomInteger, data.Locations.Primary, data.RandomString)
+}
+
 func (r StorageAccountResource) storageV1BlobProperties(data acceptance.TestData) string {
 	return fmt.Sprintf(`
 provider "azurerm" {
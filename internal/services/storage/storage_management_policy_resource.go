@@ -339,6 +339,17 @@ func resourceStorageManagementPolicyCreateOrUpdate(d *pluginsdk.ResourceData, me
 		return fmt.Errorf("expanding %s: %+v", mgmtPolicyId, err)
 	}
 
+	if managementPolicyRulesUseLastAccessTime(armRules) {
+		blobServiceClient := meta.(*clients.Client).Storage.ResourceManager.BlobService
+		enabled, err := storageAccountLastAccessTimeTrackingEnabled(ctx, blobServiceClient, *rid)
+		if err != nil {
+			return fmt.Errorf("checking last access time tracking for %s: %+v", mgmtPolicyId, err)
+		}
+		if !enabled {
+			log.Printf("[WARN] %s contains rules based on the last access time but `blob_properties.last_access_time_enabled` is not enabled on %s - these rules will not be applied", mgmtPolicyId, *rid)
+		}
+	}
+
 	parameters.Properties = &managementpolicies.ManagementPolicyProperties{
 		Policy: managementpolicies.ManagementPolicySchema{
 			Rules: armRules,
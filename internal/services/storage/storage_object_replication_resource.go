@@ -152,6 +152,10 @@ func resourceStorageObjectReplicationCreate(d *pluginsdk.ResourceData, meta inte
 		}
 	}
 
+	if err := ValidateObjectReplicationPrerequisites(ctx, meta.(*clients.Client).Storage.ResourceManager.BlobService, *srcAccount, *dstAccount); err != nil {
+		return err
+	}
+
 	props := objectreplicationpolicies.ObjectReplicationPolicy{
 		Properties: &objectreplicationpolicies.ObjectReplicationPolicyProperties{
 			SourceAccount:      srcAccount.ID(),
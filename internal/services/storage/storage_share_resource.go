@@ -9,7 +9,6 @@ import (
 	"strings"
 	"time"
 
-	"github.com/hashicorp/go-azure-sdk/resource-manager/storage/2023-01-01/storageaccounts"
 	"github.com/hashicorp/terraform-provider-azurerm/helpers/tf"
 	"github.com/hashicorp/terraform-provider-azurerm/internal/clients"
 	"github.com/hashicorp/terraform-provider-azurerm/internal/services/storage/client"
@@ -185,10 +184,8 @@ func resourceStorageShareCreate(d *pluginsdk.ResourceData, meta interface{}) err
 
 	protocol := shares.ShareProtocol(d.Get("enabled_protocol").(string))
 	if protocol == shares.NFS {
-		// Only FileStorage (whose sku tier is Premium only) storage account is able to have NFS file shares.
-		// See: https://learn.microsoft.com/en-us/azure/storage/files/storage-files-quick-create-use-linux#applies-to
-		if account.Kind != storageaccounts.KindFileStorage {
-			return fmt.Errorf("NFS File Share is only supported for Storage Account with kind %q but got `%s`", string(storageaccounts.KindFileStorage), account.Kind)
+		if err := validate.StorageAccountSupportsNfsFileShares(account.Kind, account.Tier); err != nil {
+			return err
 		}
 	}
 
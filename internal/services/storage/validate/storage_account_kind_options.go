@@ -0,0 +1,155 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package validate
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/go-azure-sdk/resource-manager/storage/2023-01-01/storageaccounts"
+)
+
+var (
+	storageKindsSupportAccessTier = map[storageaccounts.Kind]struct{}{
+		storageaccounts.KindBlobStorage: {},
+		storageaccounts.KindFileStorage: {},
+		storageaccounts.KindStorageVTwo: {},
+	}
+	storageKindsSupportHns = map[storageaccounts.Kind]struct{}{
+		storageaccounts.KindBlobStorage:      {},
+		storageaccounts.KindBlockBlobStorage: {},
+		storageaccounts.KindStorageVTwo:      {},
+	}
+	storageKindsSupportLargeFileShares = map[storageaccounts.Kind]struct{}{
+		storageaccounts.KindFileStorage: {},
+		storageaccounts.KindStorageVTwo: {},
+	}
+)
+
+// StorageAccountKindOptions are the options requested for a Storage Account whose availability depends on the
+// `account_kind` and `account_tier`.
+type StorageAccountKindOptions struct {
+	Kind storageaccounts.Kind
+	Tier storageaccounts.SkuTier
+
+	AccessTierSpecified             bool
+	HnsEnabled                      bool
+	InfrastructureEncryptionEnabled bool
+	LargeFileShareEnabled           bool
+	NfsV3Enabled                    bool
+}
+
+// StorageAccountKindOptionIssue describes an option which isn't supported for the kind/tier of Storage Account.
+type StorageAccountKindOptionIssue struct {
+	// Field is the name of the argument which can't be used.
+	Field string
+
+	// Message explains why the argument can't be used.
+	Message string
+}
+
+func (i StorageAccountKindOptionIssue) Error() string {
+	return i.Message
+}
+
+// StorageAccountKindSupportsAccessTier returns whether an Access Tier can be specified for the kind of Storage Account.
+func StorageAccountKindSupportsAccessTier(kind storageaccounts.Kind) bool {
+	_, ok := storageKindsSupportAccessTier[kind]
+	return ok
+}
+
+// StorageAccountSupportsNfsFileShares returns an error if NFS (v4.1) File Shares can't be created within the kind/tier of
+// Storage Account, which requires a Premium FileStorage account - the tier is only checked when it's known.
+// (https://learn.microsoft.com/en-us/azure/storage/files/files-nfs-protocol#support-for-azure-storage-features)
+func StorageAccountSupportsNfsFileShares(kind storageaccounts.Kind, tier storageaccounts.SkuTier) error {
+	if kind != storageaccounts.KindFileStorage || (tier != "" && tier != storageaccounts.SkuTierPremium) {
+		return fmt.Errorf("NFS File Shares are only supported for Storage Accounts with account tier `%s` and account kind `%s` but got account tier `%s` and account kind `%s`", storageaccounts.SkuTierPremium, storageaccounts.KindFileStorage, tier, kind)
+	}
+
+	return nil
+}
+
+// StorageAccountKindOptionsSupported returns an issue for each of the specified options which isn't supported by the
+// kind/tier of Storage Account, in the order they should be surfaced - or an empty slice when all are supported.
+func StorageAccountKindOptionsSupported(input StorageAccountKindOptions) []StorageAccountKindOptionIssue {
+	issues := make([]StorageAccountKindOptionIssue, 0)
+
+	if input.AccessTierSpecified {
+		// BlockBlobStorage accounts are called out separately to the other unsupported kinds since it's commonly set out of habit
+		if input.Kind == storageaccounts.KindBlockBlobStorage {
+			issues = append(issues, StorageAccountKindOptionIssue{
+				Field:   "access_tier",
+				Message: fmt.Sprintf("`access_tier` is not supported for Premium accounts where `account_kind` is set to `%s` - please remove `access_tier` from the configuration", storageaccounts.KindBlockBlobStorage),
+			})
+		} else if !StorageAccountKindSupportsAccessTier(input.Kind) {
+			issues = append(issues, StorageAccountKindOptionIssue{
+				Field:   "access_tier",
+				Message: fmt.Sprintf("`access_tier` is only available for accounts of kind set to one of: %+v", strings.Join(sortedStorageKinds(storageKindsSupportAccessTier), " / ")),
+			})
+		}
+	}
+
+	if _, ok := storageKindsSupportHns[input.Kind]; !ok && input.HnsEnabled {
+		issues = append(issues, StorageAccountKindOptionIssue{
+			Field:   "is_hns_enabled",
+			Message: fmt.Sprintf("`is_hns_enabled` can only be used for accounts with `kind` set to one of: %+v", strings.Join(sortedStorageKinds(storageKindsSupportHns), " / ")),
+		})
+	}
+
+	// NFSv3 is supported for standard general-purpose v2 storage accounts and for premium block blob storage accounts.
+	// (https://docs.microsoft.com/en-us/azure/storage/blobs/network-file-system-protocol-support-how-to#step-5-create-and-configure-a-storage-account)
+	if input.NfsV3Enabled {
+		isPremiumTierAndBlockBlobStorageKind := input.Tier == storageaccounts.SkuTierPremium && input.Kind == storageaccounts.KindBlockBlobStorage
+		isStandardTierAndStorageV2Kind := input.Tier == storageaccounts.SkuTierStandard && input.Kind == storageaccounts.KindStorageVTwo
+
+		if !input.HnsEnabled {
+			issues = append(issues, StorageAccountKindOptionIssue{
+				Field:   "nfsv3_enabled",
+				Message: "`nfsv3_enabled` can only be used when `is_hns_enabled` is `true`",
+			})
+		} else if !isPremiumTierAndBlockBlobStorageKind && !isStandardTierAndStorageV2Kind {
+			issues = append(issues, StorageAccountKindOptionIssue{
+				Field:   "nfsv3_enabled",
+				Message: "`nfsv3_enabled` can only be used with account tier `Standard` and account kind `StorageV2`, or account tier `Premium` and account kind `BlockBlobStorage`",
+			})
+		}
+	}
+
+	if input.Kind == storageaccounts.KindFileStorage && input.Tier != storageaccounts.SkuTierPremium {
+		issues = append(issues, StorageAccountKindOptionIssue{
+			Field:   "account_tier",
+			Message: "`account_tier` must be `Premium` for File Storage accounts",
+		})
+	}
+
+	if _, ok := storageKindsSupportLargeFileShares[input.Kind]; !ok && input.LargeFileShareEnabled {
+		issues = append(issues, StorageAccountKindOptionIssue{
+			Field:   "large_file_share_enabled",
+			Message: fmt.Sprintf("`large_file_share_enabled` can only be set to `true` with `account_kind` set to one of: %+v", strings.Join(sortedStorageKinds(storageKindsSupportLargeFileShares), " / ")),
+		})
+	}
+
+	if input.InfrastructureEncryptionEnabled {
+		validPremiumConfiguration := input.Tier == storageaccounts.SkuTierPremium && (input.Kind == storageaccounts.KindBlockBlobStorage || input.Kind == storageaccounts.KindFileStorage)
+		validV2Configuration := input.Kind == storageaccounts.KindStorageVTwo
+		if !validPremiumConfiguration && !validV2Configuration {
+			issues = append(issues, StorageAccountKindOptionIssue{
+				Field:   "infrastructure_encryption_enabled",
+				Message: "`infrastructure_encryption_enabled` can only be used with account kind `StorageV2`, or account tier `Premium` and account kind is one of `BlockBlobStorage` or `FileStorage`",
+			})
+		}
+	}
+
+	return issues
+}
+
+func sortedStorageKinds(input map[storageaccounts.Kind]struct{}) []string {
+	keys := make([]string, 0)
+	for key := range input {
+		keys = append(keys, string(key))
+	}
+	sort.Strings(keys)
+	return keys
+}
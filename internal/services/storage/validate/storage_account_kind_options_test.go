@@ -0,0 +1,224 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package validate
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/hashicorp/go-azure-sdk/resource-manager/storage/2023-01-01/storageaccounts"
+)
+
+func TestStorageAccountKindOptionsSupported(t *testing.T) {
+	testData := []struct {
+		name     string
+		input    StorageAccountKindOptions
+		expected []string
+	}{
+		{
+			name: "StorageV2 with everything enabled",
+			input: StorageAccountKindOptions{
+				Kind:                            storageaccounts.KindStorageVTwo,
+				Tier:                            storageaccounts.SkuTierStandard,
+				AccessTierSpecified:             true,
+				HnsEnabled:                      true,
+				InfrastructureEncryptionEnabled: true,
+				LargeFileShareEnabled:           true,
+				NfsV3Enabled:                    true,
+			},
+			expected: []string{},
+		},
+		{
+			name: "Storage (v1) with nothing enabled",
+			input: StorageAccountKindOptions{
+				Kind: storageaccounts.KindStorage,
+				Tier: storageaccounts.SkuTierStandard,
+			},
+			expected: []string{},
+		},
+		{
+			name: "Storage (v1) with everything enabled",
+			input: StorageAccountKindOptions{
+				Kind:                            storageaccounts.KindStorage,
+				Tier:                            storageaccounts.SkuTierStandard,
+				AccessTierSpecified:             true,
+				HnsEnabled:                      true,
+				InfrastructureEncryptionEnabled: true,
+				LargeFileShareEnabled:           true,
+				NfsV3Enabled:                    true,
+			},
+			expected: []string{"access_tier", "is_hns_enabled", "nfsv3_enabled", "large_file_share_enabled", "infrastructure_encryption_enabled"},
+		},
+		{
+			name: "BlockBlobStorage with an access tier",
+			input: StorageAccountKindOptions{
+				Kind:                storageaccounts.KindBlockBlobStorage,
+				Tier:                storageaccounts.SkuTierPremium,
+				AccessTierSpecified: true,
+			},
+			expected: []string{"access_tier"},
+		},
+		{
+			name: "Premium BlockBlobStorage with HNS, NFSv3 and infrastructure encryption",
+			input: StorageAccountKindOptions{
+				Kind:                            storageaccounts.KindBlockBlobStorage,
+				Tier:                            storageaccounts.SkuTierPremium,
+				HnsEnabled:                      true,
+				InfrastructureEncryptionEnabled: true,
+				NfsV3Enabled:                    true,
+			},
+			expected: []string{},
+		},
+		{
+			name: "BlockBlobStorage with large file shares",
+			input: StorageAccountKindOptions{
+				Kind:                  storageaccounts.KindBlockBlobStorage,
+				Tier:                  storageaccounts.SkuTierPremium,
+				LargeFileShareEnabled: true,
+			},
+			expected: []string{"large_file_share_enabled"},
+		},
+		{
+			name: "NFSv3 without HNS",
+			input: StorageAccountKindOptions{
+				Kind:         storageaccounts.KindStorageVTwo,
+				Tier:         storageaccounts.SkuTierStandard,
+				NfsV3Enabled: true,
+			},
+			expected: []string{"nfsv3_enabled"},
+		},
+		{
+			name: "NFSv3 on Premium StorageV2",
+			input: StorageAccountKindOptions{
+				Kind:         storageaccounts.KindStorageVTwo,
+				Tier:         storageaccounts.SkuTierPremium,
+				HnsEnabled:   true,
+				NfsV3Enabled: true,
+			},
+			expected: []string{"nfsv3_enabled"},
+		},
+		{
+			name: "Premium FileStorage with an access tier, large file shares and infrastructure encryption",
+			input: StorageAccountKindOptions{
+				Kind:                            storageaccounts.KindFileStorage,
+				Tier:                            storageaccounts.SkuTierPremium,
+				AccessTierSpecified:             true,
+				InfrastructureEncryptionEnabled: true,
+				LargeFileShareEnabled:           true,
+			},
+			expected: []string{},
+		},
+		{
+			name: "Standard FileStorage",
+			input: StorageAccountKindOptions{
+				Kind: storageaccounts.KindFileStorage,
+				Tier: storageaccounts.SkuTierStandard,
+			},
+			expected: []string{"account_tier"},
+		},
+		{
+			name: "Standard FileStorage with infrastructure encryption",
+			input: StorageAccountKindOptions{
+				Kind:                            storageaccounts.KindFileStorage,
+				Tier:                            storageaccounts.SkuTierStandard,
+				InfrastructureEncryptionEnabled: true,
+			},
+			expected: []string{"account_tier", "infrastructure_encryption_enabled"},
+		},
+		{
+			name: "BlobStorage with HNS and infrastructure encryption",
+			input: StorageAccountKindOptions{
+				Kind:                            storageaccounts.KindBlobStorage,
+				Tier:                            storageaccounts.SkuTierStandard,
+				AccessTierSpecified:             true,
+				HnsEnabled:                      true,
+				InfrastructureEncryptionEnabled: true,
+			},
+			expected: []string{"infrastructure_encryption_enabled"},
+		},
+	}
+
+	for _, v := range testData {
+		t.Logf("[DEBUG] Testing %q..", v.name)
+
+		fields := make([]string, 0)
+		for _, issue := range StorageAccountKindOptionsSupported(v.input) {
+			if issue.Message == "" {
+				t.Fatalf("expected a message for the issue with %q", issue.Field)
+			}
+			fields = append(fields, issue.Field)
+		}
+
+		if !reflect.DeepEqual(fields, v.expected) {
+			t.Fatalf("expected issues for %+v but got %+v", v.expected, fields)
+		}
+	}
+}
+
+func TestStorageAccountKindSupportsAccessTier(t *testing.T) {
+	testData := map[storageaccounts.Kind]bool{
+		storageaccounts.KindBlobStorage:      true,
+		storageaccounts.KindBlockBlobStorage: false,
+		storageaccounts.KindFileStorage:      true,
+		storageaccounts.KindStorage:          false,
+		storageaccounts.KindStorageVTwo:      true,
+	}
+
+	for kind, expected := range testData {
+		t.Logf("[DEBUG] Testing %q", kind)
+
+		if actual := StorageAccountKindSupportsAccessTier(kind); actual != expected {
+			t.Fatalf("expected %t but got %t", expected, actual)
+		}
+	}
+}
+
+func TestStorageAccountSupportsNfsFileShares(t *testing.T) {
+	testData := []struct {
+		kind     storageaccounts.Kind
+		tier     storageaccounts.SkuTier
+		expected bool
+	}{
+		{
+			kind:     storageaccounts.KindFileStorage,
+			tier:     storageaccounts.SkuTierPremium,
+			expected: true,
+		},
+		{
+			// the tier isn't known
+			kind:     storageaccounts.KindFileStorage,
+			tier:     "",
+			expected: true,
+		},
+		{
+			kind:     storageaccounts.KindFileStorage,
+			tier:     storageaccounts.SkuTierStandard,
+			expected: false,
+		},
+		{
+			kind:     storageaccounts.KindStorageVTwo,
+			tier:     storageaccounts.SkuTierPremium,
+			expected: false,
+		},
+		{
+			kind:     storageaccounts.KindStorageVTwo,
+			tier:     storageaccounts.SkuTierStandard,
+			expected: false,
+		},
+		{
+			kind:     storageaccounts.KindBlockBlobStorage,
+			tier:     storageaccounts.SkuTierPremium,
+			expected: false,
+		},
+	}
+
+	for _, v := range testData {
+		t.Logf("[DEBUG] Testing %q / %q", v.kind, v.tier)
+
+		err := StorageAccountSupportsNfsFileShares(v.kind, v.tier)
+		if actual := err == nil; actual != v.expected {
+			t.Fatalf("expected %t but got %t (%+v)", v.expected, actual, err)
+		}
+	}
+}
@@ -0,0 +1,31 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package validate
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/go-azure-sdk/resource-manager/storage/2023-01-01/storageaccounts"
+)
+
+// unsupportedReplicationTypesForKind are the Replication Types which can't be used with each kind of Storage Account.
+var unsupportedReplicationTypesForKind = map[storageaccounts.Kind][]string{
+	// Per local test, Storage (v1) accounts support ZRS but not GZRS/RAGZRS.
+	storageaccounts.KindStorage:          {"GZRS", "RAGZRS"},
+	storageaccounts.KindBlobStorage:      {"ZRS", "GZRS", "RAGZRS"},
+	storageaccounts.KindBlockBlobStorage: {"GRS", "RAGRS", "GZRS", "RAGZRS"},
+	storageaccounts.KindFileStorage:      {"GRS", "RAGRS", "GZRS", "RAGZRS"},
+}
+
+// ReplicationTypeSupportedForKind returns an error if the specified Replication Type isn't supported for the kind of Storage Account.
+func ReplicationTypeSupportedForKind(kind storageaccounts.Kind, replicationType string) error {
+	for _, unsupported := range unsupportedReplicationTypesForKind[kind] {
+		if strings.EqualFold(replicationType, unsupported) {
+			return fmt.Errorf("an `account_replication_type` of %q isn't supported when `account_kind` is set to %q", replicationType, string(kind))
+		}
+	}
+
+	return nil
+}
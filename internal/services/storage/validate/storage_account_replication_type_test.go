@@ -0,0 +1,46 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package validate
+
+import (
+	"testing"
+
+	"github.com/hashicorp/go-azure-sdk/resource-manager/storage/2023-01-01/storageaccounts"
+)
+
+func TestReplicationTypeSupportedForKind(t *testing.T) {
+	supported := map[storageaccounts.Kind][]string{
+		storageaccounts.KindStorage:          {"LRS", "GRS", "RAGRS", "ZRS"},
+		storageaccounts.KindStorageVTwo:      {"LRS", "GRS", "RAGRS", "ZRS", "GZRS", "RAGZRS"},
+		storageaccounts.KindBlobStorage:      {"LRS", "GRS", "RAGRS"},
+		storageaccounts.KindBlockBlobStorage: {"LRS", "ZRS"},
+		storageaccounts.KindFileStorage:      {"LRS", "ZRS"},
+	}
+	unsupported := map[storageaccounts.Kind][]string{
+		storageaccounts.KindStorage:          {"GZRS", "RAGZRS"},
+		storageaccounts.KindBlobStorage:      {"ZRS", "GZRS", "RAGZRS"},
+		storageaccounts.KindBlockBlobStorage: {"GRS", "RAGRS", "GZRS", "RAGZRS"},
+		storageaccounts.KindFileStorage:      {"GRS", "RAGRS", "GZRS", "RAGZRS"},
+	}
+
+	for kind, replicationTypes := range supported {
+		for _, replicationType := range replicationTypes {
+			t.Logf("[DEBUG] Testing %q / %q", kind, replicationType)
+
+			if err := ReplicationTypeSupportedForKind(kind, replicationType); err != nil {
+				t.Fatalf("Expected %q to be supported for %q but got: %+v", replicationType, kind, err)
+			}
+		}
+	}
+
+	for kind, replicationTypes := range unsupported {
+		for _, replicationType := range replicationTypes {
+			t.Logf("[DEBUG] Testing %q / %q", kind, replicationType)
+
+			if err := ReplicationTypeSupportedForKind(kind, replicationType); err == nil {
+				t.Fatalf("Expected %q to be unsupported for %q", replicationType, kind)
+			}
+		}
+	}
+}
@@ -0,0 +1,43 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package validate
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+)
+
+var storageAccountSasExpirationPeriodRegex = regexp.MustCompile(`^(\d{1,5})\.([01]?\d|2[0-3]):([0-5]?\d):([0-5]?\d)$`)
+
+// StorageAccountSasExpirationPeriod validates that the SAS Expiration Period is in the format `DD.HH:MM:SS`,
+// where the hours, minutes and seconds are within their normal bounds and the overall period is greater than zero.
+func StorageAccountSasExpirationPeriod(i interface{}, k string) (warnings []string, errors []error) {
+	v, ok := i.(string)
+	if !ok {
+		errors = append(errors, fmt.Errorf("expected type of %s to be string", k))
+		return warnings, errors
+	}
+
+	matches := storageAccountSasExpirationPeriodRegex.FindStringSubmatch(v)
+	if matches == nil {
+		errors = append(errors, fmt.Errorf("expected %s to be in the format `DD.HH:MM:SS` (e.g. `1.12:00:00`), got %q", k, v))
+		return warnings, errors
+	}
+
+	total := 0
+	for _, part := range matches[1:] {
+		value, err := strconv.Atoi(part)
+		if err != nil {
+			errors = append(errors, fmt.Errorf("parsing %q within %s: %+v", part, k, err))
+			return warnings, errors
+		}
+		total += value
+	}
+	if total == 0 {
+		errors = append(errors, fmt.Errorf("expected %s to be greater than `0.00:00:00`, got %q", k, v))
+	}
+
+	return warnings, errors
+}
@@ -0,0 +1,74 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package validate
+
+import (
+	"testing"
+)
+
+func TestStorageAccountSasExpirationPeriod(t *testing.T) {
+	testData := []struct {
+		Input    string
+		Expected bool
+	}{
+		{
+			Input:    "",
+			Expected: false,
+		},
+		{
+			Input:    "1.00:00:00",
+			Expected: true,
+		},
+		{
+			Input:    "0.01:30:00",
+			Expected: true,
+		},
+		{
+			Input:    "365.23:59:59",
+			Expected: true,
+		},
+		{
+			Input:    "0.00:00:00",
+			Expected: false,
+		},
+		{
+			Input:    "1.24:00:00",
+			Expected: false,
+		},
+		{
+			Input:    "1.00:60:00",
+			Expected: false,
+		},
+		{
+			Input:    "1.00:00:60",
+			Expected: false,
+		},
+		{
+			Input:    "1.15:5:05",
+			Expected: true,
+		},
+		{
+			Input:    "00:30:00",
+			Expected: false,
+		},
+		{
+			Input:    "1d",
+			Expected: false,
+		},
+		{
+			Input:    "123456.00:00:00",
+			Expected: false,
+		},
+	}
+
+	for _, v := range testData {
+		t.Logf("[DEBUG] Testing %q", v.Input)
+
+		_, errors := StorageAccountSasExpirationPeriod(v.Input, "expiration_period")
+		actual := len(errors) == 0
+		if v.Expected != actual {
+			t.Fatalf("Expected %t but got %t", v.Expected, actual)
+		}
+	}
+}
@@ -0,0 +1,47 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package validate
+
+import (
+	"fmt"
+)
+
+// StorageAnalyticsDefaultVersion is the version of Storage Analytics used when one isn't specified.
+const StorageAnalyticsDefaultVersion = "1.0"
+
+// StorageAnalyticsLoggingVersions are the supported versions of Storage Analytics Logging.
+var StorageAnalyticsLoggingVersions = []string{
+	"1.0",
+	"2.0",
+}
+
+// StorageAnalyticsMetricsVersions are the supported versions of Storage Analytics Metrics.
+var StorageAnalyticsMetricsVersions = []string{
+	"1.0",
+}
+
+func StorageAnalyticsLoggingVersion(i interface{}, k string) (warnings []string, errors []error) {
+	return validateStorageAnalyticsVersion(i, k, StorageAnalyticsLoggingVersions)
+}
+
+func StorageAnalyticsMetricsVersion(i interface{}, k string) (warnings []string, errors []error) {
+	return validateStorageAnalyticsVersion(i, k, StorageAnalyticsMetricsVersions)
+}
+
+func validateStorageAnalyticsVersion(i interface{}, k string, valid []string) (warnings []string, errors []error) {
+	v, ok := i.(string)
+	if !ok {
+		errors = append(errors, fmt.Errorf("expected type of %s to be string", k))
+		return warnings, errors
+	}
+
+	for _, str := range valid {
+		if v == str {
+			return warnings, errors
+		}
+	}
+
+	errors = append(errors, fmt.Errorf("expected %s to be one of %v, got %q", k, valid, v))
+	return warnings, errors
+}
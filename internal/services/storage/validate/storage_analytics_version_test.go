@@ -0,0 +1,80 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package validate
+
+import (
+	"testing"
+)
+
+func TestStorageAnalyticsLoggingVersion(t *testing.T) {
+	testData := []struct {
+		Input    string
+		Expected bool
+	}{
+		{
+			Input:    "",
+			Expected: false,
+		},
+		{
+			Input:    "1.0",
+			Expected: true,
+		},
+		{
+			Input:    "2.0",
+			Expected: true,
+		},
+		{
+			Input:    "1",
+			Expected: false,
+		},
+		{
+			Input:    "3.0",
+			Expected: false,
+		},
+	}
+
+	for _, v := range testData {
+		t.Logf("[DEBUG] Testing %q", v.Input)
+
+		_, errors := StorageAnalyticsLoggingVersion(v.Input, "version")
+		actual := len(errors) == 0
+		if v.Expected != actual {
+			t.Fatalf("Expected %t but got %t", v.Expected, actual)
+		}
+	}
+}
+
+func TestStorageAnalyticsMetricsVersion(t *testing.T) {
+	testData := []struct {
+		Input    string
+		Expected bool
+	}{
+		{
+			Input:    "",
+			Expected: false,
+		},
+		{
+			Input:    "1.0",
+			Expected: true,
+		},
+		{
+			Input:    "2.0",
+			Expected: false,
+		},
+		{
+			Input:    "1",
+			Expected: false,
+		},
+	}
+
+	for _, v := range testData {
+		t.Logf("[DEBUG] Testing %q", v.Input)
+
+		_, errors := StorageAnalyticsMetricsVersion(v.Input, "version")
+		actual := len(errors) == 0
+		if v.Expected != actual {
+			t.Fatalf("Expected %t but got %t", v.Expected, actual)
+		}
+	}
+}